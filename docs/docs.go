@@ -23,39 +23,422 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/admin/link/import": {
+        "/v1/admin/audio/import": {
             "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
-                "description": "import links",
+                "description": "start the job which imports audio metadata of sentences in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.\nthe counts of a dry run job are the numbers of the rows which would be written. audios are checked against the sentences in the database",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "tatoeba"
                 ],
-                "summary": "import links",
+                "summary": "import audio metadata",
                 "parameters": [
                     {
                         "type": "file",
-                        "description": "links.csv",
+                        "description": "sentences_with_audio.csv. it can be compressed with gzip or bzip2 and archived with tar, like sentences_with_audio.tar.bz2",
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "insert",
+                            "sync"
+                        ],
+                        "type": "string",
+                        "default": "insert",
+                        "description": "insert adds new audios. sync also updates changed audios and removes audios absent from the file",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ISO 639-3 codes of the languages, repeated or separated by commas. only audios of sentences of the languages are imported. all languages if empty",
+                        "name": "languages",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImportJobStartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": ""
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/job/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "find the status and progress of the import job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "find import job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
                         "description": ""
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/job/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "request the running import job to stop. The job stops after the current batch",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "cancel import job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImportJobResponse"
+                        }
+                    },
                     "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
                         "description": ""
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/job/{id}/rejected_rows": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "download the rows of the import job rejected as malformed or as texts longer than the limit, with their row numbers in the file, codes and reasons, as TSV",
+                "produces": [
+                    "text/tab-separated-values"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "download rejected rows",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "row_number, code (malformed or text_too_long) and reason separated by tabs",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": ""
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
                     "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/license/import": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "start the job which marks the sentences listed in sentences_CC0.csv as CC0-1.0 in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.\nthe counts of a dry run job are the numbers of the rows which would be written. licenses are checked against the sentences in the database, and the changed licenses are counted as updates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "import sentence licenses",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "sentences_CC0.csv. it can be compressed with gzip or bzip2 and archived with tar, like sentences_CC0.tar.bz2",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "insert",
+                            "sync"
+                        ],
+                        "type": "string",
+                        "default": "insert",
+                        "description": "insert marks the listed sentences as CC0-1.0. sync also gives CC-BY-2.0-FR back to the CC0-1.0 sentences absent from the file",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ISO 639-3 codes of the languages, repeated or separated by commas. only licenses of sentences of the languages are updated. all languages if empty",
+                        "name": "languages",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImportJobStartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
                         "description": ""
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/link/import": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "start the job which imports links in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.\nthe counts of a dry run job are the numbers of the rows which would be written. links are checked against the sentences in the database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "import links",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "links.csv. it can be compressed with gzip or bzip2 and archived with tar, like links.tar.bz2",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "insert",
+                            "sync"
+                        ],
+                        "type": "string",
+                        "default": "insert",
+                        "description": "insert adds new links. sync also removes links absent from the file, which must be sorted by the source sentence number",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ISO 639-3 codes of the languages, repeated or separated by commas. only links between sentences of the languages are imported. all languages if empty",
+                        "name": "languages",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImportJobStartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": ""
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -67,7 +450,10 @@ var doc = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "import sentences",
+                "description": "start the job which imports sentences in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.\nthe counts of a dry run job are the numbers of the rows which would be written",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "tatoeba"
                 ],
@@ -75,24 +461,204 @@ var doc = `{
                 "parameters": [
                     {
                         "type": "file",
-                        "description": "***_sentences_detailed.tsv",
+                        "description": "***_sentences_detailed.tsv. it can be compressed with gzip or bzip2 and archived with tar, like sentences_detailed.tar.bz2",
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "insert",
+                            "sync"
+                        ],
+                        "type": "string",
+                        "default": "insert",
+                        "description": "insert adds new sentences. sync also updates changed sentences and removes sentences of the imported languages in the file absent from it",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ISO 639-3 codes of the languages to import, repeated or separated by commas. sentences of other languages are skipped. all languages if empty",
+                        "name": "languages",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them",
+                        "name": "dryRun",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": ""
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImportJobStartResponse"
+                        }
                     },
                     "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
                         "description": ""
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/tag/import": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "start the job which imports tags in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.\nthe counts of a dry run job are the numbers of the rows which would be written. tags are checked against the sentences in the database",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "import tags",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "tags.csv. it can be compressed with gzip or bzip2 and archived with tar, like tags.tar.bz2",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "insert",
+                            "sync"
+                        ],
+                        "type": "string",
+                        "default": "insert",
+                        "description": "insert adds new tags. sync also removes tags absent from the file, which must be sorted by the sentence number",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ISO 639-3 codes of the languages, repeated or separated by commas. only tags of sentences of the languages are imported. all languages if empty",
+                        "name": "languages",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them",
+                        "name": "dryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ImportJobStartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": ""
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
                     "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sentence/find_by_numbers": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "find sentences by up to 500 sentence numbers and return the sentence numbers which are not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "find sentences by sentence numbers",
+                "parameters": [
+                    {
+                        "description": "parameter to find sentences",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TatoebaSentenceBatchFindParameter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TatoebaSentenceBatchFindResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
                         "description": ""
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -114,7 +680,62 @@ var doc = `{
                 "tags": [
                     "tatoeba"
                 ],
-                "summary": "import links",
+                "summary": "import links",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sentence number",
+                        "name": "sentenceNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.TatoebaSentenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sentence/{sentenceNumber}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "find the sentence and its translations grouped by language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tatoeba"
+                ],
+                "summary": "find translations of the sentence",
                 "parameters": [
                     {
                         "type": "integer",
@@ -122,20 +743,41 @@ var doc = `{
                         "name": "sentenceNumber",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include translations linked through a pivot sentence",
+                        "name": "includeIndirect",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.TatoebaSentenceResponse"
+                            "$ref": "#/definitions/entity.TatoebaSentenceTranslationsResponse"
                         }
                     },
                     "400": {
-                        "description": ""
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": ""
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -147,7 +789,7 @@ var doc = `{
                         "BasicAuth": []
                     }
                 ],
-                "description": "import links",
+                "description": "find pair of sentences",
                 "consumes": [
                     "application/json"
                 ],
@@ -157,7 +799,7 @@ var doc = `{
                 "tags": [
                     "tatoeba"
                 ],
-                "summary": "import links",
+                "summary": "find pair of sentences",
                 "parameters": [
                     {
                         "description": "parameter to find sentences",
@@ -177,26 +819,316 @@ var doc = `{
                         }
                     },
                     "400": {
-                        "description": ""
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     },
                     "401": {
                         "description": ""
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ErrorResponse"
+                        }
                     }
                 }
             }
         }
     },
     "definitions": {
+        "entity.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is one of invalid_argument, not_found, conflict and internal_error",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message describes the invalid argument, such as the field and the rule it breaks, if the code is invalid_argument.\nOtherwise it is the fixed description of the code. The details are logged with the request ID",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "RequestID is the ID of the request in the logs",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "cancelRequested": {
+                    "type": "boolean"
+                },
+                "deleteCount": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "DryRun is true if the job did not write the rows. Its counts are the numbers of the rows which would be written",
+                    "type": "boolean"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "fileChecksum": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "importCount": {
+                    "description": "ImportCount is the number of inserted rows",
+                    "type": "integer"
+                },
+                "jobType": {
+                    "description": "JobType is one of sentence, link, tag, audio and license",
+                    "type": "string"
+                },
+                "languages": {
+                    "description": "Languages are the imported languages. Empty means all languages",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mode": {
+                    "description": "Mode is one of insert and sync",
+                    "type": "string"
+                },
+                "readCount": {
+                    "type": "integer"
+                },
+                "rejectCount": {
+                    "description": "RejectCount is the number of rejected rows, which are malformed or have texts longer than the limit. They are included in SkipCount",
+                    "type": "integer"
+                },
+                "rejectedRowsPath": {
+                    "description": "RejectedRowsPath is the path to download the rejected rows report. It is set when some rows are rejected",
+                    "type": "string"
+                },
+                "resumedFromJobId": {
+                    "description": "ResumedFromJobID is the job from whose checkpoint the job resumed",
+                    "type": "integer"
+                },
+                "skipCount": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is one of running, succeeded, failed and cancelled. A job whose process stopped fails with the error message interrupted",
+                    "type": "string"
+                },
+                "updateCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ImportJobStartResponse": {
+            "type": "object",
+            "properties": {
+                "jobId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.TatoebaAudioResponse": {
+            "type": "object",
+            "properties": {
+                "attributionUrl": {
+                    "description": "AttributionURL is the page to credit the speaker. Empty means the profile of the speaker on Tatoeba",
+                    "type": "string"
+                },
+                "author": {
+                    "description": "Author is the username of the speaker",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "license": {
+                    "description": "License is the license of the recording. Empty means that the recording cannot be reused",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.TatoebaSentenceBatchFindParameter": {
+            "type": "object",
+            "required": [
+                "sentenceNumbers"
+            ],
+            "properties": {
+                "sentenceNumbers": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entity.TatoebaSentenceBatchFindResponse": {
+            "type": "object",
+            "properties": {
+                "missingSentenceNumbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TatoebaSentenceResponse"
+                    }
+                }
+            }
+        },
+        "entity.TatoebaSentenceFilterParameter": {
+            "type": "object",
+            "required": [
+                "excludeAuthors",
+                "excludeTags",
+                "includeAuthors",
+                "includeTags"
+            ],
+            "properties": {
+                "excludeAuthors": {
+                    "description": "ExcludeAuthors excludes sentences written by the authors",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "excludeTags": {
+                    "description": "ExcludeTags excludes sentences with the tags, like \"@needs native check\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "includeAuthors": {
+                    "description": "IncludeAuthors restricts sentences to those written by one of the authors",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "includeTags": {
+                    "description": "IncludeTags restricts sentences to those with one of the tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "license": {
+                    "description": "License is one of CC-BY-2.0-FR and CC0-1.0. All licenses if empty",
+                    "type": "string",
+                    "enum": [
+                        "CC-BY-2.0-FR",
+                        "CC0-1.0"
+                    ]
+                },
+                "maxDifficulty": {
+                    "description": "MaxDifficulty is the maximum difficulty from 0 (easiest) to 100 (hardest)",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "maxLength": {
+                    "description": "MaxLength is the maximum number of characters. 0 means no limit",
+                    "type": "integer"
+                },
+                "maxWordCount": {
+                    "description": "MaxWordCount is the maximum number of words. 0 means no limit",
+                    "type": "integer"
+                },
+                "minDifficulty": {
+                    "description": "MinDifficulty is the minimum difficulty from 0 (easiest) to 100 (hardest)",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "minLength": {
+                    "description": "MinLength is the minimum number of characters",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minWordCount": {
+                    "description": "MinWordCount is the minimum number of words. Each character of languages written without spaces is counted as a word",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "updatedAfter": {
+                    "description": "UpdatedAfter restricts sentences to those updated after the time",
+                    "type": "string"
+                },
+                "updatedBefore": {
+                    "description": "UpdatedBefore restricts sentences to those updated before the time",
+                    "type": "string"
+                },
+                "withAudioOnly": {
+                    "description": "WithAudioOnly restricts sentences to those with a recording by a native speaker",
+                    "type": "boolean"
+                }
+            }
+        },
         "entity.TatoebaSentenceFindParameter": {
             "type": "object",
             "required": [
-                "pageNo",
                 "pageSize"
             ],
             "properties": {
+                "cursor": {
+                    "description": "Cursor is nextCursor of the previous response. pageNo is ignored if it is specified. It is available only if sortBy is sentenceNumber and random is false",
+                    "type": "string"
+                },
+                "dstFilter": {
+                    "description": "DstFilter is the filter of destination sentences",
+                    "$ref": "#/definitions/entity.TatoebaSentenceFilterParameter"
+                },
+                "dstKeyword": {
+                    "description": "DstKeyword is the keyword for the destination sentence. It is required if keywordTarget is both",
+                    "type": "string"
+                },
+                "dstLang3": {
+                    "type": "string"
+                },
                 "keyword": {
                     "type": "string"
                 },
+                "keywordTarget": {
+                    "description": "KeywordTarget is one of src, dst, either and both. The default is src",
+                    "type": "string",
+                    "enum": [
+                        "src",
+                        "dst",
+                        "either",
+                        "both"
+                    ]
+                },
+                "matchMode": {
+                    "description": "MatchMode is one of substring, word, phrase and prefix. The default is substring.\nWords of languages written without spaces, like Japanese and Chinese, are matched as substrings in word and prefix modes.\nA keyword without letters or digits matches nothing",
+                    "type": "string",
+                    "enum": [
+                        "substring",
+                        "word",
+                        "phrase",
+                        "prefix"
+                    ]
+                },
+                "maxHops": {
+                    "description": "MaxHops is 1 or 2. 2 includes pairs linked through a pivot sentence. The default is 1",
+                    "type": "integer",
+                    "maximum": 2,
+                    "minimum": 1
+                },
                 "pageNo": {
                     "type": "integer",
                     "minimum": 1
@@ -207,15 +1139,52 @@ var doc = `{
                 },
                 "random": {
                     "type": "boolean"
+                },
+                "seed": {
+                    "description": "Seed is the seed of the random order. Each seed shuffles source sentences in a different order. The same seed returns the same order across pages",
+                    "type": "integer",
+                    "maximum": 2147483646,
+                    "minimum": 0
+                },
+                "sortBy": {
+                    "description": "SortBy is one of sentenceNumber, updatedAt, textLength and difficulty of the source sentence. The default is sentenceNumber. It is ignored if random is true",
+                    "type": "string",
+                    "enum": [
+                        "sentenceNumber",
+                        "updatedAt",
+                        "textLength",
+                        "difficulty"
+                    ]
+                },
+                "sortOrder": {
+                    "description": "SortOrder is one of asc and desc. The default is asc",
+                    "type": "string",
+                    "enum": [
+                        "asc",
+                        "desc"
+                    ]
+                },
+                "srcFilter": {
+                    "description": "SrcFilter is the filter of source sentences",
+                    "$ref": "#/definitions/entity.TatoebaSentenceFilterParameter"
+                },
+                "srcLang3": {
+                    "type": "string"
                 }
             }
         },
         "entity.TatoebaSentencePair": {
             "type": "object",
             "properties": {
+                "direct": {
+                    "type": "boolean"
+                },
                 "dst": {
                     "$ref": "#/definitions/entity.TatoebaSentenceResponse"
                 },
+                "pivot": {
+                    "$ref": "#/definitions/entity.TatoebaSentenceResponse"
+                },
                 "src": {
                     "$ref": "#/definitions/entity.TatoebaSentenceResponse"
                 }
@@ -224,29 +1193,58 @@ var doc = `{
         "entity.TatoebaSentencePairFindResponse": {
             "type": "object",
             "properties": {
+                "nextCursor": {
+                    "type": "string"
+                },
                 "results": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/entity.TatoebaSentencePair"
                     }
                 },
+                "seed": {
+                    "type": "integer"
+                },
                 "totalCount": {
                     "type": "integer"
+                },
+                "totalCountCapped": {
+                    "type": "boolean"
                 }
             }
         },
         "entity.TatoebaSentenceResponse": {
             "type": "object",
             "properties": {
+                "attribution": {
+                    "description": "Attribution is the credit line to display with the sentence",
+                    "type": "string"
+                },
+                "audios": {
+                    "description": "Audios are the recordings of the sentence by native speakers",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TatoebaAudioResponse"
+                    }
+                },
                 "author": {
                     "type": "string"
                 },
+                "difficulty": {
+                    "type": "integer"
+                },
+                "hasAudio": {
+                    "type": "boolean"
+                },
                 "lang2": {
-                    "type": "string",
-                    "enum": [
-                        "ja",
-                        "en"
-                    ]
+                    "type": "string"
+                },
+                "lang3": {
+                    "type": "string"
+                },
+                "license": {
+                    "description": "License is the license of the sentence, CC-BY-2.0-FR or CC0-1.0",
+                    "type": "string"
                 },
                 "sentenceNumber": {
                     "type": "integer"
@@ -258,6 +1256,51 @@ var doc = `{
                     "type": "string"
                 }
             }
+        },
+        "entity.TatoebaSentenceTranslationGroup": {
+            "type": "object",
+            "properties": {
+                "lang2": {
+                    "type": "string"
+                },
+                "lang3": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TatoebaSentenceTranslationResponse"
+                    }
+                }
+            }
+        },
+        "entity.TatoebaSentenceTranslationResponse": {
+            "type": "object",
+            "properties": {
+                "direct": {
+                    "type": "boolean"
+                },
+                "pivot": {
+                    "$ref": "#/definitions/entity.TatoebaSentenceResponse"
+                },
+                "sentence": {
+                    "$ref": "#/definitions/entity.TatoebaSentenceResponse"
+                }
+            }
+        },
+        "entity.TatoebaSentenceTranslationsResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TatoebaSentenceTranslationGroup"
+                    }
+                },
+                "sentence": {
+                    "$ref": "#/definitions/entity.TatoebaSentenceResponse"
+                }
+            }
         }
     },
     "securityDefinitions": {
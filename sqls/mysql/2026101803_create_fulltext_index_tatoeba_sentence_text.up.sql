@@ -0,0 +1,7 @@
+-- words of most languages are separated by spaces and are indexed by the standard parser
+alter table `tatoeba_sentence` add fulltext index `ft_tatoeba_sentence_text` (`text`);
+
+-- languages written without spaces, and Korean whose particles are attached to words, are indexed by n-grams.
+-- the languages must be the same as ngramLang3s in src/app/gateway/tatoeba_sentence_fulltext.go
+alter table `tatoeba_sentence` add column `ngram_text` varchar(500) as (if(`lang3` in ('cmn', 'jpn', 'khm', 'kor', 'lao', 'lzh', 'mya', 'tha', 'wuu', 'yue', 'zho'), `text`, '')) stored;
+alter table `tatoeba_sentence` add fulltext index `ft_tatoeba_sentence_ngram_text` (`ngram_text`) with parser ngram;
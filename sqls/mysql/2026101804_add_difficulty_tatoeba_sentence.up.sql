@@ -0,0 +1,2 @@
+alter table `tatoeba_sentence` add column `difficulty` int not null default 0;
+create index `idx_tatoeba_sentence_lang3_difficulty` on `tatoeba_sentence`(`lang3`, `difficulty`);
@@ -0,0 +1,13 @@
+create table `tatoeba_import_job` (
+ `id` int not null auto_increment
+,`job_type` varchar(20) character set ascii not null
+,`status` varchar(20) character set ascii not null
+,`read_count` int not null default 0
+,`import_count` int not null default 0
+,`skip_count` int not null default 0
+,`error_message` varchar(1000) not null default ''
+,`cancel_requested` tinyint(1) not null default 0
+,`started_at` datetime not null
+,`finished_at` datetime
+,primary key(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
@@ -0,0 +1,11 @@
+create table `tatoeba_audio` (
+ `audio_id` int not null
+,`sentence_number` int not null
+,`author` varchar(100) not null
+,`license` varchar(100) not null default ''
+,`attribution_url` varchar(1000) not null default ''
+,primary key(`audio_id`)
+,foreign key(`sentence_number`) references `tatoeba_sentence`(`sentence_number`) on delete cascade
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;
+
+create index `idx_tatoeba_audio_sentence_number` on `tatoeba_audio`(`sentence_number`);
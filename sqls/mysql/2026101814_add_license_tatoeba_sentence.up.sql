@@ -0,0 +1,2 @@
+alter table `tatoeba_sentence` add column `license` varchar(20) character set ascii not null default 'CC-BY-2.0-FR';
+create index `idx_tatoeba_sentence_license` on `tatoeba_sentence`(`license`);
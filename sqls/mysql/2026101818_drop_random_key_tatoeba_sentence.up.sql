@@ -0,0 +1 @@
+alter table `tatoeba_sentence` drop index `idx_tatoeba_sentence_lang3_random_key`, drop column `random_key`;
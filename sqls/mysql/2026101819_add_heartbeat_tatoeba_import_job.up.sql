@@ -0,0 +1 @@
+alter table `tatoeba_import_job` add column `heartbeat_at` datetime;
@@ -0,0 +1 @@
+create index `idx_tatoeba_sentence_lang3` on `tatoeba_sentence`(`lang3`);
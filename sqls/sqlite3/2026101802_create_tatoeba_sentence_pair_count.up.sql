@@ -0,0 +1,12 @@
+create table `tatoeba_sentence_pair_count` (
+ `src_lang3` varchar(3) not null
+,`dst_lang3` varchar(3) not null
+,`pair_count` int not null
+,primary key(`src_lang3`, `dst_lang3`)
+);
+
+insert into `tatoeba_sentence_pair_count` (`src_lang3`, `dst_lang3`, `pair_count`)
+ select T1.`lang3`, T3.`lang3`, count(*) from `tatoeba_sentence` as T1
+ inner join `tatoeba_link` as T2 on T1.`sentence_number` = T2.`from`
+ inner join `tatoeba_sentence` as T3 on T3.`sentence_number` = T2.`to`
+ group by T1.`lang3`, T3.`lang3`;
@@ -0,0 +1,22 @@
+-- the default unicode61 tokenizer splits words by spaces and punctuation.
+-- languages written without spaces are matched by LIKE instead, see ngramLang3s in src/app/gateway/tatoeba_sentence_fulltext.go
+create virtual table `tatoeba_sentence_fts` using fts5(
+ `text`
+,content='tatoeba_sentence'
+,content_rowid='sentence_number'
+);
+
+insert into `tatoeba_sentence_fts`(`tatoeba_sentence_fts`) values('rebuild');
+
+create trigger `tatoeba_sentence_fts_insert` after insert on `tatoeba_sentence` begin
+  insert into `tatoeba_sentence_fts`(rowid, `text`) values (new.`sentence_number`, new.`text`);
+end;
+
+create trigger `tatoeba_sentence_fts_delete` after delete on `tatoeba_sentence` begin
+  insert into `tatoeba_sentence_fts`(`tatoeba_sentence_fts`, rowid, `text`) values('delete', old.`sentence_number`, old.`text`);
+end;
+
+create trigger `tatoeba_sentence_fts_update` after update on `tatoeba_sentence` begin
+  insert into `tatoeba_sentence_fts`(`tatoeba_sentence_fts`, rowid, `text`) values('delete', old.`sentence_number`, old.`text`);
+  insert into `tatoeba_sentence_fts`(rowid, `text`) values (new.`sentence_number`, new.`text`);
+end;
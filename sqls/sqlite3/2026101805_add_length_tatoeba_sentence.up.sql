@@ -0,0 +1,5 @@
+-- word_count is computed by domain.CountWords, which SQL cannot reproduce. existing sentences keep 0 and are excluded by minWordCount
+-- until their languages are imported again, because each sentence import recounts the words of every sentence of the imported languages
+alter table `tatoeba_sentence` add column `text_length` int not null default 0;
+alter table `tatoeba_sentence` add column `word_count` int not null default 0;
+update `tatoeba_sentence` set `text_length` = length(`text`);
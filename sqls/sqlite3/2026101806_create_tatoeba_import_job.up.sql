@@ -0,0 +1,12 @@
+create table `tatoeba_import_job` (
+ `id` integer primary key autoincrement
+,`job_type` varchar(20) not null
+,`status` varchar(20) not null
+,`read_count` int not null default 0
+,`import_count` int not null default 0
+,`skip_count` int not null default 0
+,`error_message` varchar(1000) not null default ''
+,`cancel_requested` tinyint(1) not null default 0
+,`started_at` datetime not null
+,`finished_at` datetime
+);
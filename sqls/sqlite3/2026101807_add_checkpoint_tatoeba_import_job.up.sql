@@ -0,0 +1,3 @@
+alter table `tatoeba_import_job` add column `file_checksum` varchar(64) not null default '';
+alter table `tatoeba_import_job` add column `resumed_from_job_id` int;
+create index `idx_tatoeba_import_job_checksum` on `tatoeba_import_job`(`job_type`, `file_checksum`);
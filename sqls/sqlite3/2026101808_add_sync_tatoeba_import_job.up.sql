@@ -0,0 +1,3 @@
+alter table `tatoeba_import_job` add column `mode` varchar(20) not null default 'insert';
+alter table `tatoeba_import_job` add column `update_count` int not null default 0;
+alter table `tatoeba_import_job` add column `delete_count` int not null default 0;
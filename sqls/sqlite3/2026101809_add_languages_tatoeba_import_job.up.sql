@@ -0,0 +1 @@
+alter table `tatoeba_import_job` add column `languages` varchar(2000) not null default '';
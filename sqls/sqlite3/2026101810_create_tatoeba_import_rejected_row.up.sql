@@ -0,0 +1,9 @@
+alter table `tatoeba_import_job` add column `reject_count` int not null default 0;
+
+create table `tatoeba_import_rejected_row` (
+ `job_id` int not null
+,`row_number` int not null
+,`reason` varchar(1000) not null
+,primary key(`job_id`, `row_number`)
+,foreign key(`job_id`) references `tatoeba_import_job`(`id`)
+);
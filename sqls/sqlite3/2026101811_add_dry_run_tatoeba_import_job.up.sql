@@ -0,0 +1 @@
+alter table `tatoeba_import_job` add column `dry_run` tinyint(1) not null default 0;
@@ -0,0 +1,8 @@
+create table `tatoeba_tag` (
+ `sentence_number` int not null
+,`tag_name` varchar(100) not null
+,primary key(`sentence_number`, `tag_name`)
+,foreign key(`sentence_number`) references `tatoeba_sentence`(`sentence_number`)
+);
+
+create index `idx_tatoeba_tag_tag_name` on `tatoeba_tag`(`tag_name`);
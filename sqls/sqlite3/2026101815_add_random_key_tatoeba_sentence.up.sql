@@ -0,0 +1,3 @@
+alter table `tatoeba_sentence` add column `random_key` int not null default 0;
+update `tatoeba_sentence` set `random_key` = ((`sentence_number` * 48271) % 2147483647) * ((`sentence_number` * 48271) % 2147483647) % 2147483647;
+create index `idx_tatoeba_sentence_lang3_random_key` on `tatoeba_sentence`(`lang3`, `random_key`);
@@ -0,0 +1 @@
+alter table `tatoeba_import_rejected_row` add column `code` varchar(20) not null default 'malformed';
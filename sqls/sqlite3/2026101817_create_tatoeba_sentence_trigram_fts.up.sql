@@ -0,0 +1,39 @@
+-- languages written without spaces are indexed by trigrams, which match substrings of 3 or more characters.
+-- only their sentences are indexed. the languages must be the same as ngramLang3s in src/app/gateway/tatoeba_sentence_fulltext.go
+create virtual table `tatoeba_sentence_trigram_fts` using fts5(
+ `text`
+,content='tatoeba_sentence'
+,content_rowid='sentence_number'
+,tokenize='trigram'
+);
+
+insert into `tatoeba_sentence_trigram_fts`(rowid, `text`)
+select `sentence_number`, `text` from `tatoeba_sentence`
+where `lang3` in ('cmn', 'jpn', 'khm', 'kor', 'lao', 'lzh', 'mya', 'tha', 'wuu', 'yue', 'zho');
+
+create trigger `tatoeba_sentence_trigram_fts_insert` after insert on `tatoeba_sentence`
+when new.`lang3` in ('cmn', 'jpn', 'khm', 'kor', 'lao', 'lzh', 'mya', 'tha', 'wuu', 'yue', 'zho') begin
+  insert into `tatoeba_sentence_trigram_fts`(rowid, `text`) values (new.`sentence_number`, new.`text`);
+end;
+
+create trigger `tatoeba_sentence_trigram_fts_delete` after delete on `tatoeba_sentence`
+when old.`lang3` in ('cmn', 'jpn', 'khm', 'kor', 'lao', 'lzh', 'mya', 'tha', 'wuu', 'yue', 'zho') begin
+  insert into `tatoeba_sentence_trigram_fts`(`tatoeba_sentence_trigram_fts`, rowid, `text`) values('delete', old.`sentence_number`, old.`text`);
+end;
+
+-- the old text is removed before the new text is added, so both are in one trigger
+create trigger `tatoeba_sentence_trigram_fts_update` after update of `lang3`, `text` on `tatoeba_sentence` begin
+  insert into `tatoeba_sentence_trigram_fts`(`tatoeba_sentence_trigram_fts`, rowid, `text`)
+  select 'delete', old.`sentence_number`, old.`text`
+  where old.`lang3` in ('cmn', 'jpn', 'khm', 'kor', 'lao', 'lzh', 'mya', 'tha', 'wuu', 'yue', 'zho');
+  insert into `tatoeba_sentence_trigram_fts`(rowid, `text`)
+  select new.`sentence_number`, new.`text`
+  where new.`lang3` in ('cmn', 'jpn', 'khm', 'kor', 'lao', 'lzh', 'mya', 'tha', 'wuu', 'yue', 'zho');
+end;
+
+-- the word index is updated only when the text changes, not when the scores or the licenses do
+drop trigger `tatoeba_sentence_fts_update`;
+create trigger `tatoeba_sentence_fts_update` after update of `text` on `tatoeba_sentence` begin
+  insert into `tatoeba_sentence_fts`(`tatoeba_sentence_fts`, rowid, `text`) values('delete', old.`sentence_number`, old.`text`);
+  insert into `tatoeba_sentence_fts`(rowid, `text`) values (new.`sentence_number`, new.`text`);
+end;
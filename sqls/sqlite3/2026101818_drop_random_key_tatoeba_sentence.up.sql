@@ -0,0 +1,2 @@
+drop index `idx_tatoeba_sentence_lang3_random_key`;
+alter table `tatoeba_sentence` drop column `random_key`;
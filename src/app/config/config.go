@@ -69,6 +69,11 @@ type SwaggerConfig struct {
 	Schema  string `yaml:"schema"`
 }
 
+type ImportConfig struct {
+	// TextLimitLength is the maximum length of an imported sentence in bytes. It must not exceed 500, the length of the text column
+	TextLimitLength int `yaml:"textLimitLength" validate:"gte=1,lte=500"`
+}
+
 type DebugConfig struct {
 	GinMode bool `yaml:"ginMode"`
 	Wait    bool `yaml:"wait"`
@@ -83,6 +88,7 @@ type Config struct {
 	Shutdown *ShutdownConfig `yaml:"shutdown" validate:"required"`
 	Log      *LogConfig      `yaml:"log" validate:"required"`
 	Swagger  *SwaggerConfig  `yaml:"swagger" validate:"required"`
+	Import   *ImportConfig   `yaml:"import" validate:"required"`
 	Debug    *DebugConfig    `yaml:"debug"`
 }
 
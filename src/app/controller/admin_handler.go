@@ -1,15 +1,28 @@
 package controller
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/converter"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/entity"
 	handlerhelper "github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/helper"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/usecase"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/lib/controller/helper"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
 	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/lib/log"
 )
@@ -17,119 +30,386 @@ import (
 type AdminHandler interface {
 	ImportSentences(c *gin.Context)
 	ImportLinks(c *gin.Context)
+	ImportTags(c *gin.Context)
+	ImportAudios(c *gin.Context)
+	ImportLicenses(c *gin.Context)
+	FindImportJob(c *gin.Context)
+	CancelImportJob(c *gin.Context)
+	FindImportJobRejectedRows(c *gin.Context)
 }
 
 type adminHandler struct {
-	adminUsecase                         usecase.AdminUsecase
-	newTatoebaSentenceAddParameterReader func(reader io.Reader) service.TatoebaSentenceAddParameterIterator
-	newTatoebaLinkAddParameterReader     func(reader io.Reader) service.TatoebaLinkAddParameterIterator
+	adminUsecase                             usecase.AdminUsecase
+	newTatoebaSentenceAddParameterReader     func(reader io.Reader) service.TatoebaSentenceAddParameterIterator
+	newTatoebaLinkAddParameterReader         func(reader io.Reader) service.TatoebaLinkAddParameterIterator
+	newTatoebaTagAddParameterReader          func(reader io.Reader) service.TatoebaTagAddParameterIterator
+	newTatoebaAudioAddParameterReader        func(reader io.Reader) service.TatoebaAudioAddParameterIterator
+	newTatoebaSentenceLicenseParameterReader func(reader io.Reader) service.TatoebaSentenceLicenseParameterIterator
 }
 
-func NewAdminHandler(adminUsecase usecase.AdminUsecase, newTatoebaSentenceAddParameterReader func(reader io.Reader) service.TatoebaSentenceAddParameterIterator, newTatoebaLinkAddParameterReader func(reader io.Reader) service.TatoebaLinkAddParameterIterator) AdminHandler {
+func NewAdminHandler(adminUsecase usecase.AdminUsecase, newTatoebaSentenceAddParameterReader func(reader io.Reader) service.TatoebaSentenceAddParameterIterator, newTatoebaLinkAddParameterReader func(reader io.Reader) service.TatoebaLinkAddParameterIterator, newTatoebaTagAddParameterReader func(reader io.Reader) service.TatoebaTagAddParameterIterator, newTatoebaAudioAddParameterReader func(reader io.Reader) service.TatoebaAudioAddParameterIterator, newTatoebaSentenceLicenseParameterReader func(reader io.Reader) service.TatoebaSentenceLicenseParameterIterator) AdminHandler {
 	return &adminHandler{
-		adminUsecase:                         adminUsecase,
-		newTatoebaSentenceAddParameterReader: newTatoebaSentenceAddParameterReader,
-		newTatoebaLinkAddParameterReader:     newTatoebaLinkAddParameterReader,
+		adminUsecase:                             adminUsecase,
+		newTatoebaSentenceAddParameterReader:     newTatoebaSentenceAddParameterReader,
+		newTatoebaLinkAddParameterReader:         newTatoebaLinkAddParameterReader,
+		newTatoebaTagAddParameterReader:          newTatoebaTagAddParameterReader,
+		newTatoebaAudioAddParameterReader:        newTatoebaAudioAddParameterReader,
+		newTatoebaSentenceLicenseParameterReader: newTatoebaSentenceLicenseParameterReader,
 	}
 }
 
 // ImportSentences godoc
 // @Summary     import sentences
-// @Description import sentences
+// @Description start the job which imports sentences in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.
+// @Description the counts of a dry run job are the numbers of the rows which would be written
 // @Tags        tatoeba
-// @Param       file formData file true "***_sentences_detailed.tsv"
-// @Success     200
-// @Failure     400
+// @Produce     json
+// @Param       file formData file true "***_sentences_detailed.tsv. it can be compressed with gzip or bzip2 and archived with tar, like sentences_detailed.tar.bz2"
+// @Param       mode formData string false "insert adds new sentences. sync also updates changed sentences and removes sentences of the imported languages in the file absent from it" Enums(insert, sync) default(insert)
+// @Param       languages formData []string false "ISO 639-3 codes of the languages to import, repeated or separated by commas. sentences of other languages are skipped. all languages if empty" collectionFormat(multi)
+// @Param       dryRun formData bool false "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them" default(false)
+// @Success     202 {object} entity.ImportJobStartResponse
+// @Failure     400 {object} entity.ErrorResponse
 // @Failure     401
-// @Failure     500
+// @Failure     409 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
 // @Router      /v1/admin/sentence/import [post]
 // @Security    BasicAuth
 func (h *adminHandler) ImportSentences(c *gin.Context) {
+	h.handleImport(c, func(ctx context.Context, file *tempFile, option service.ImportOption) (int, error) {
+		return h.adminUsecase.ImportSentences(ctx, h.newTatoebaSentenceAddParameterReader(file), file, option)
+	})
+}
+
+// ImportLinks godoc
+// @Summary     import links
+// @Description start the job which imports links in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.
+// @Description the counts of a dry run job are the numbers of the rows which would be written. links are checked against the sentences in the database
+// @Tags        tatoeba
+// @Produce     json
+// @Param       file formData file true "links.csv. it can be compressed with gzip or bzip2 and archived with tar, like links.tar.bz2"
+// @Param       mode formData string false "insert adds new links. sync also removes links absent from the file, which must be sorted by the source sentence number" Enums(insert, sync) default(insert)
+// @Param       languages formData []string false "ISO 639-3 codes of the languages, repeated or separated by commas. only links between sentences of the languages are imported. all languages if empty" collectionFormat(multi)
+// @Param       dryRun formData bool false "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them" default(false)
+// @Success     202 {object} entity.ImportJobStartResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     409 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/admin/link/import [post]
+// @Security    BasicAuth
+func (h *adminHandler) ImportLinks(c *gin.Context) {
+	h.handleImport(c, func(ctx context.Context, file *tempFile, option service.ImportOption) (int, error) {
+		return h.adminUsecase.ImportLinks(ctx, h.newTatoebaLinkAddParameterReader(file), file, option)
+	})
+}
+
+// ImportTags godoc
+// @Summary     import tags
+// @Description start the job which imports tags in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.
+// @Description the counts of a dry run job are the numbers of the rows which would be written. tags are checked against the sentences in the database
+// @Tags        tatoeba
+// @Produce     json
+// @Param       file formData file true "tags.csv. it can be compressed with gzip or bzip2 and archived with tar, like tags.tar.bz2"
+// @Param       mode formData string false "insert adds new tags. sync also removes tags absent from the file, which must be sorted by the sentence number" Enums(insert, sync) default(insert)
+// @Param       languages formData []string false "ISO 639-3 codes of the languages, repeated or separated by commas. only tags of sentences of the languages are imported. all languages if empty" collectionFormat(multi)
+// @Param       dryRun formData bool false "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them" default(false)
+// @Success     202 {object} entity.ImportJobStartResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     409 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/admin/tag/import [post]
+// @Security    BasicAuth
+func (h *adminHandler) ImportTags(c *gin.Context) {
+	h.handleImport(c, func(ctx context.Context, file *tempFile, option service.ImportOption) (int, error) {
+		return h.adminUsecase.ImportTags(ctx, h.newTatoebaTagAddParameterReader(file), file, option)
+	})
+}
+
+// ImportAudios godoc
+// @Summary     import audio metadata
+// @Description start the job which imports audio metadata of sentences in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.
+// @Description the counts of a dry run job are the numbers of the rows which would be written. audios are checked against the sentences in the database
+// @Tags        tatoeba
+// @Produce     json
+// @Param       file formData file true "sentences_with_audio.csv. it can be compressed with gzip or bzip2 and archived with tar, like sentences_with_audio.tar.bz2"
+// @Param       mode formData string false "insert adds new audios. sync also updates changed audios and removes audios absent from the file" Enums(insert, sync) default(insert)
+// @Param       languages formData []string false "ISO 639-3 codes of the languages, repeated or separated by commas. only audios of sentences of the languages are imported. all languages if empty" collectionFormat(multi)
+// @Param       dryRun formData bool false "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them" default(false)
+// @Success     202 {object} entity.ImportJobStartResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     409 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/admin/audio/import [post]
+// @Security    BasicAuth
+func (h *adminHandler) ImportAudios(c *gin.Context) {
+	h.handleImport(c, func(ctx context.Context, file *tempFile, option service.ImportOption) (int, error) {
+		return h.adminUsecase.ImportAudios(ctx, h.newTatoebaAudioAddParameterReader(file), file, option)
+	})
+}
+
+// ImportLicenses godoc
+// @Summary     import sentence licenses
+// @Description start the job which marks the sentences listed in sentences_CC0.csv as CC0-1.0 in the background. if the last import of the same file did not succeed, the job resumes from its checkpoint.
+// @Description the counts of a dry run job are the numbers of the rows which would be written. licenses are checked against the sentences in the database, and the changed licenses are counted as updates
+// @Tags        tatoeba
+// @Produce     json
+// @Param       file formData file true "sentences_CC0.csv. it can be compressed with gzip or bzip2 and archived with tar, like sentences_CC0.tar.bz2"
+// @Param       mode formData string false "insert marks the listed sentences as CC0-1.0. sync also gives CC-BY-2.0-FR back to the CC0-1.0 sentences absent from the file" Enums(insert, sync) default(insert)
+// @Param       languages formData []string false "ISO 639-3 codes of the languages, repeated or separated by commas. only licenses of sentences of the languages are updated. all languages if empty" collectionFormat(multi)
+// @Param       dryRun formData bool false "validate the file and count the rows which would be imported, skipped and rejected against the database without writing them" default(false)
+// @Success     202 {object} entity.ImportJobStartResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     409 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/admin/license/import [post]
+// @Security    BasicAuth
+func (h *adminHandler) ImportLicenses(c *gin.Context) {
+	h.handleImport(c, func(ctx context.Context, file *tempFile, option service.ImportOption) (int, error) {
+		return h.adminUsecase.ImportLicenses(ctx, h.newTatoebaSentenceLicenseParameterReader(file), file, option)
+	})
+}
+
+// FindImportJob godoc
+// @Summary     find import job
+// @Description find the status and progress of the import job
+// @Tags        tatoeba
+// @Produce     json
+// @Param       id path int true "Job ID"
+// @Success     200 {object} entity.ImportJobResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     404 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/admin/job/{id} [get]
+// @Security    BasicAuth
+func (h *adminHandler) FindImportJob(c *gin.Context) {
 	ctx := c.Request.Context()
-	logger := log.FromContext(ctx)
 	handlerhelper.HandleFunction(c, func() error {
-		file, err := c.FormFile("file")
+		id, err := helper.GetIntFromPath(c, "id")
 		if err != nil {
-			if errors.Is(err, http.ErrMissingFile) {
-				logger.Warnf("err: %+v", err)
-				c.Status(http.StatusBadRequest)
-				return nil
-			}
-			if errors.Is(err, http.ErrNotMultipart) {
-				logger.Warnf("err: %+v", err)
-				c.Status(http.StatusBadRequest)
-				return nil
-			}
-			return err
+			return libD.ToInvalidArgumentError("id", err)
 		}
 
-		multipartFile, err := file.Open()
+		job, err := h.adminUsecase.FindImportJob(ctx, id)
+		if err != nil {
+			return liberrors.Errorf("failed to FindImportJob. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToImportJobResponse(ctx, job))
+		return nil
+	}, h.errorHandle)
+}
+
+// CancelImportJob godoc
+// @Summary     cancel import job
+// @Description request the running import job to stop. The job stops after the current batch
+// @Tags        tatoeba
+// @Produce     json
+// @Param       id path int true "Job ID"
+// @Success     202 {object} entity.ImportJobResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     404 {object} entity.ErrorResponse
+// @Failure     409 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/admin/job/{id}/cancel [post]
+// @Security    BasicAuth
+func (h *adminHandler) CancelImportJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	handlerhelper.HandleFunction(c, func() error {
+		id, err := helper.GetIntFromPath(c, "id")
 		if err != nil {
-			return liberrors.Errorf("failed to file.Open. err: %w", err)
+			return libD.ToInvalidArgumentError("id", err)
 		}
-		defer multipartFile.Close()
 
-		iterator := h.newTatoebaSentenceAddParameterReader(multipartFile)
+		if err := h.adminUsecase.CancelImportJob(ctx, id); err != nil {
+			return liberrors.Errorf("failed to CancelImportJob. err: %w", err)
+		}
 
-		if err := h.adminUsecase.ImportSentences(ctx, iterator); err != nil {
-			return liberrors.Errorf("failed to ImportSentences. err: %w", err)
+		job, err := h.adminUsecase.FindImportJob(ctx, id)
+		if err != nil {
+			return liberrors.Errorf("failed to FindImportJob. err: %w", err)
 		}
 
-		c.Status(http.StatusOK)
+		c.JSON(http.StatusAccepted, converter.ToImportJobResponse(ctx, job))
 		return nil
 	}, h.errorHandle)
 }
 
-// ImportLinks godoc
-// @Summary     import links
-// @Description import links
+// FindImportJobRejectedRows godoc
+// @Summary     download rejected rows
+// @Description download the rows of the import job rejected as malformed or as texts longer than the limit, with their row numbers in the file, codes and reasons, as TSV
 // @Tags        tatoeba
-// @Param       file formData file true "links.csv"
-// @Success     200
-// @Failure     400
+// @Produce     text/tab-separated-values
+// @Param       id path int true "Job ID"
+// @Success     200 {string} string "row_number, code (malformed or text_too_long) and reason separated by tabs"
+// @Failure     400 {object} entity.ErrorResponse
 // @Failure     401
-// @Failure     500
-// @Router      /v1/admin/link/import [post]
+// @Failure     404 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/admin/job/{id}/rejected_rows [get]
 // @Security    BasicAuth
-func (h *adminHandler) ImportLinks(c *gin.Context) {
+func (h *adminHandler) FindImportJobRejectedRows(c *gin.Context) {
+	ctx := c.Request.Context()
+	handlerhelper.HandleFunction(c, func() error {
+		id, err := helper.GetIntFromPath(c, "id")
+		if err != nil {
+			return libD.ToInvalidArgumentError("id", err)
+		}
+
+		rows, err := h.adminUsecase.FindImportJobRejectedRows(ctx, id)
+		if err != nil {
+			return liberrors.Errorf("failed to FindImportJobRejectedRows. err: %w", err)
+		}
+
+		var b strings.Builder
+		b.WriteString("row_number\tcode\treason\n")
+		for _, row := range rows {
+			// the reason is a single line of the report
+			reason := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(row.GetReason())
+			fmt.Fprintf(&b, "%d\t%s\t%s\n", row.GetRowNumber(), row.GetCode(), reason)
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=import_job_%d_rejected_rows.tsv", id))
+		c.Data(http.StatusOK, "text/tab-separated-values; charset=utf-8", []byte(b.String()))
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *adminHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Errorf("adminHandler. err: %v", err)
+	return false
+}
+
+// handleImport saves the uploaded file and starts the import job of it with the option in the form.
+// start owns the file, which is closed when the job finishes
+func (h *adminHandler) handleImport(c *gin.Context, start func(ctx context.Context, file *tempFile, option service.ImportOption) (int, error)) {
 	ctx := c.Request.Context()
 	logger := log.FromContext(ctx)
 	handlerhelper.HandleFunction(c, func() error {
 		file, err := c.FormFile("file")
 		if err != nil {
-			if errors.Is(err, http.ErrMissingFile) {
+			if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
 				logger.Warnf("err: %+v", err)
-				c.Status(http.StatusBadRequest)
-				return nil
-			}
-			if errors.Is(err, http.ErrNotMultipart) {
-				logger.Warnf("err: %+v", err)
-				c.Status(http.StatusBadRequest)
-				return nil
+				return libD.ToInvalidArgumentError("file", err)
 			}
 			return err
 		}
 
-		multipartFile, err := file.Open()
+		form, err := bindImportOptionForm(c)
+		if err != nil {
+			return err
+		}
+
+		tmpFile, err := saveTempFile(file)
 		if err != nil {
-			return liberrors.Errorf("failed to file.Open. err: %w", err)
+			return liberrors.Errorf("failed to saveTempFile. err: %w", err)
 		}
-		defer multipartFile.Close()
 
-		iterator := h.newTatoebaLinkAddParameterReader(multipartFile)
+		option, err := service.NewImportOption(tmpFile.checksum, form.mode, form.lang3s, form.dryRun)
+		if err != nil {
+			tmpFile.Close()
+			return liberrors.Errorf("failed to NewImportOption. err: %w", err)
+		}
 
-		if err := h.adminUsecase.ImportLinks(ctx, iterator); err != nil {
-			return liberrors.Errorf("failed to ImportLinks. err: %w", err)
+		jobID, err := start(ctx, tmpFile, option)
+		if err != nil {
+			return liberrors.Errorf("failed to start import job. err: %w", err)
 		}
 
-		c.Status(http.StatusOK)
+		c.JSON(http.StatusAccepted, entity.ImportJobStartResponse{JobID: jobID})
 		return nil
 	}, h.errorHandle)
 }
 
-func (h *adminHandler) errorHandle(c *gin.Context, err error) bool {
-	ctx := c.Request.Context()
-	logger := log.FromContext(ctx)
-	logger.Errorf("adminHandler. err: %v", err)
-	return false
+type importOptionForm struct {
+	mode   service.ImportMode
+	lang3s []domain.Lang3
+	dryRun bool
+}
+
+// bindImportOptionForm returns the mode, the languages and whether to dry run in the form. The default mode is ImportModeInsert and no languages means all languages.
+// Languages can be repeated or separated by commas
+func bindImportOptionForm(c *gin.Context) (*importOptionForm, error) {
+	mode := service.ImportMode(c.DefaultPostForm("mode", string(service.ImportModeInsert)))
+	switch mode {
+	case service.ImportModeInsert, service.ImportModeSync:
+	default:
+		return nil, libD.NewInvalidArgumentError("mode", fmt.Sprintf("%s is neither insert nor sync", mode))
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultPostForm("dryRun", "false"))
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("dryRun", err)
+	}
+
+	lang3s := make([]domain.Lang3, 0)
+	for _, value := range c.PostFormArray("languages") {
+		for _, language := range strings.Split(value, ",") {
+			language = strings.TrimSpace(language)
+			if language == "" {
+				continue
+			}
+			lang3, err := domain.NewLang3(language)
+			if err != nil {
+				return nil, libD.ToInvalidArgumentError("languages", err)
+			}
+			lang3s = append(lang3s, lang3)
+		}
+	}
+
+	return &importOptionForm{
+		mode:   mode,
+		lang3s: lang3s,
+		dryRun: dryRun,
+	}, nil
+}
+
+// tempFile removes the file when it is closed
+type tempFile struct {
+	*os.File
+	// checksum is the SHA-256 checksum of the file, which identifies the file when the import resumes
+	checksum string
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	if removeErr := os.Remove(f.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// saveTempFile copies the uploaded file, which is removed at the end of the request, so that the import job can read it in the background
+func saveTempFile(file *multipart.FileHeader) (*tempFile, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, liberrors.Errorf("failed to file.Open. err: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp("", "tatoeba-import-*")
+	if err != nil {
+		return nil, liberrors.Errorf("failed to CreateTemp. err: %w", err)
+	}
+	tmp := &tempFile{File: dst}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), src); err != nil {
+		tmp.Close()
+		return nil, liberrors.Errorf("failed to copy file. err: %w", err)
+	}
+	tmp.checksum = hex.EncodeToString(hash.Sum(nil))
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		tmp.Close()
+		return nil, liberrors.Errorf("failed to Seek. err: %w", err)
+	}
+
+	return tmp, nil
 }
@@ -15,7 +15,7 @@ import (
 	"github.com/kujilabo/cocotola-tatoeba-api/src/lib/controller/middleware"
 )
 
-func NewRouter(adminUsecase usecase.AdminUsecase, userUsecase usecase.UserUsecase, corsConfig cors.Config, appConfig *config.AppConfig, authConfig *config.AuthConfig, debugConfig *config.DebugConfig) *gin.Engine {
+func NewRouter(adminUsecase usecase.AdminUsecase, userUsecase usecase.UserUsecase, corsConfig cors.Config, appConfig *config.AppConfig, authConfig *config.AuthConfig, importConfig *config.ImportConfig, debugConfig *config.DebugConfig) *gin.Engine {
 	if !debugConfig.GinMode {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -43,22 +43,39 @@ func NewRouter(adminUsecase usecase.AdminUsecase, userUsecase usecase.UserUsecas
 		v1.Use(authMiddleware)
 		{
 			newSentenceReader := func(reader io.Reader) service.TatoebaSentenceAddParameterIterator {
-				return gateway.NewTatoebaSentenceAddParameterReader(reader)
+				return gateway.NewTatoebaSentenceAddParameterReader(reader, importConfig.TextLimitLength)
 			}
 			newLinkReader := func(reader io.Reader) service.TatoebaLinkAddParameterIterator {
 				return gateway.NewTatoebaLinkAddParameterReader(reader)
 			}
+			newTagReader := func(reader io.Reader) service.TatoebaTagAddParameterIterator {
+				return gateway.NewTatoebaTagAddParameterReader(reader)
+			}
+			newAudioReader := func(reader io.Reader) service.TatoebaAudioAddParameterIterator {
+				return gateway.NewTatoebaAudioAddParameterReader(reader)
+			}
+			newLicenseReader := func(reader io.Reader) service.TatoebaSentenceLicenseParameterIterator {
+				return gateway.NewTatoebaCC0SentenceLicenseParameterReader(reader)
+			}
 
 			admin := v1.Group("admin")
-			adminHandler := NewAdminHandler(adminUsecase, newSentenceReader, newLinkReader)
+			adminHandler := NewAdminHandler(adminUsecase, newSentenceReader, newLinkReader, newTagReader, newAudioReader, newLicenseReader)
 			admin.POST("sentence/import", adminHandler.ImportSentences)
 			admin.POST("link/import", adminHandler.ImportLinks)
+			admin.POST("tag/import", adminHandler.ImportTags)
+			admin.POST("audio/import", adminHandler.ImportAudios)
+			admin.POST("license/import", adminHandler.ImportLicenses)
+			admin.GET("job/:id", adminHandler.FindImportJob)
+			admin.POST("job/:id/cancel", adminHandler.CancelImportJob)
+			admin.GET("job/:id/rejected_rows", adminHandler.FindImportJobRejectedRows)
 		}
 		{
 			user := v1.Group("user")
 			userHandler := NewUserHandler(userUsecase)
 			user.POST("sentence_pair/find", userHandler.FindSentencePairs)
 			user.GET("sentence/:sentenceNumber", userHandler.FindSentenceBySentenceNumber)
+			user.POST("sentence/find_by_numbers", userHandler.FindSentencesBySentenceNumbers)
+			user.GET("sentence/:sentenceNumber/translations", userHandler.FindTranslationsBySentenceNumber)
 		}
 	}
 
@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/entity"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func ToImportJobResponse(ctx context.Context, job service.ImportJob) *entity.ImportJobResponse {
+	languages := make([]string, len(job.GetOption().GetLang3s()))
+	for i, lang3 := range job.GetOption().GetLang3s() {
+		languages[i] = lang3.String()
+	}
+
+	rejectedRowsPath := ""
+	if job.GetRejectCount() > 0 {
+		rejectedRowsPath = fmt.Sprintf("/v1/admin/job/%d/rejected_rows", job.GetID())
+	}
+
+	return &entity.ImportJobResponse{
+		ID:               job.GetID(),
+		JobType:          string(job.GetJobType()),
+		Mode:             string(job.GetOption().GetMode()),
+		DryRun:           job.GetOption().IsDryRun(),
+		Status:           string(job.GetStatus()),
+		ReadCount:        job.GetReadCount(),
+		ImportCount:      job.GetImportCount(),
+		UpdateCount:      job.GetUpdateCount(),
+		DeleteCount:      job.GetDeleteCount(),
+		SkipCount:        job.GetSkipCount(),
+		RejectCount:      job.GetRejectCount(),
+		RejectedRowsPath: rejectedRowsPath,
+		ErrorMessage:     job.GetErrorMessage(),
+		CancelRequested:  job.IsCancelRequested(),
+		StartedAt:        job.GetStartedAt(),
+		FinishedAt:       job.GetFinishedAt(),
+		FileChecksum:     job.GetOption().GetFileChecksum(),
+		Languages:        languages,
+		ResumedFromJobID: job.GetResumedFromJobID(),
+	}
+}
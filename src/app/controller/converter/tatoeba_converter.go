@@ -2,60 +2,282 @@ package converter
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
 
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/entity"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
 )
 
+// ToTatoebaSentenceSearchCondition returns the condition of the request. Errors of the request parameters are InvalidArgumentError
 func ToTatoebaSentenceSearchCondition(ctx context.Context, param *entity.TatoebaSentenceFindParameter) (service.TatoebaSentenceSearchCondition, error) {
-	return service.NewTatoebaSentenceSearchCondition(param.PageNo, param.PageSize, param.Keyword, param.Random)
+	srcLang3, err := toLang3(param.SrcLang3, domain.Lang3ENG)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("srcLang3", err)
+	}
+
+	dstLang3, err := toLang3(param.DstLang3, domain.Lang3JPN)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("dstLang3", err)
+	}
+
+	keyword, err := toTatoebaSentenceKeywordCondition(param)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("keyword", err)
+	}
+
+	srcFilter, err := toTatoebaSentenceFilter(param.SrcFilter)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("srcFilter", err)
+	}
+
+	dstFilter, err := toTatoebaSentenceFilter(param.DstFilter)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("dstFilter", err)
+	}
+
+	sortBy := service.SortBySentenceNumber
+	if param.SortBy != "" {
+		sortBy = service.SortBy(param.SortBy)
+	}
+
+	sortOrder := service.SortOrderAsc
+	if param.SortOrder != "" {
+		sortOrder = service.SortOrder(param.SortOrder)
+	}
+
+	maxHops := 1
+	if param.MaxHops != 0 {
+		maxHops = param.MaxHops
+	}
+
+	pageNo := param.PageNo
+	var cursor service.TatoebaSentencePairCursor
+	if param.Cursor != "" {
+		// the random order is paged by pageNo because it has no position to start after
+		if param.Random {
+			return nil, libD.NewInvalidArgumentError("cursor", "it is not available with random")
+		}
+		if sortBy != service.SortBySentenceNumber {
+			return nil, libD.NewInvalidArgumentError("cursor", fmt.Sprintf("it is not available with sortBy %s", sortBy))
+		}
+		tmpCursor, err := service.ParseTatoebaSentencePairCursor(param.Cursor)
+		if err != nil {
+			return nil, liberrors.Errorf("invalid cursor. err: %w", err)
+		}
+		cursor = tmpCursor
+		pageNo = 1
+	}
+
+	seed := 0
+	if param.Seed != nil {
+		seed = *param.Seed
+	} else if param.Random {
+		tmpSeed, err := rand.Int(rand.Reader, big.NewInt(service.MaxSeed+1))
+		if err != nil {
+			return nil, liberrors.Errorf("failed to generate seed. err: %w", err)
+		}
+		seed = int(tmpSeed.Int64())
+	}
+
+	condition, err := service.NewTatoebaSentenceSearchCondition(pageNo, param.PageSize, keyword, param.Random, seed, srcLang3, dstLang3, maxHops, srcFilter, dstFilter, sortBy, sortOrder, cursor)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("parameter", err)
+	}
+
+	return condition, nil
+}
+
+func toTatoebaSentenceKeywordCondition(param *entity.TatoebaSentenceFindParameter) (service.TatoebaSentenceKeywordCondition, error) {
+	if param.Keyword == "" && param.DstKeyword == "" {
+		return nil, nil
+	}
+
+	matchMode := service.MatchModeSubstring
+	if param.MatchMode != "" {
+		matchMode = service.MatchMode(param.MatchMode)
+	}
+
+	target := service.KeywordTargetSrc
+	if param.KeywordTarget != "" {
+		target = service.KeywordTarget(param.KeywordTarget)
+	}
+
+	return service.NewTatoebaSentenceKeywordCondition(param.Keyword, param.DstKeyword, matchMode, target)
+}
+
+// toTatoebaSentenceFilter returns nil if the filter is not specified
+func toTatoebaSentenceFilter(param *entity.TatoebaSentenceFilterParameter) (service.TatoebaSentenceFilter, error) {
+	if param == nil {
+		return nil, nil
+	}
+
+	minDifficulty := domain.MinDifficulty
+	if param.MinDifficulty != nil {
+		minDifficulty = *param.MinDifficulty
+	}
+
+	maxDifficulty := domain.MaxDifficulty
+	if param.MaxDifficulty != nil {
+		maxDifficulty = *param.MaxDifficulty
+	}
+
+	return service.NewTatoebaSentenceFilter(minDifficulty, maxDifficulty, param.MinLength, param.MaxLength, param.MinWordCount, param.MaxWordCount, param.IncludeAuthors, param.ExcludeAuthors, param.IncludeTags, param.ExcludeTags, param.WithAudioOnly, service.TatoebaSentenceLicense(param.License), param.UpdatedAfter, param.UpdatedBefore)
+}
+
+func toLang3(value string, defaultValue domain.Lang3) (domain.Lang3, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return domain.NewLang3(value)
 }
 
 func ToTatoebaSentenceFindResponse(ctx context.Context, result service.TatoebaSentencePairSearchResult) (*entity.TatoebaSentencePairFindResponse, error) {
 	entities := make([]entity.TatoebaSentencePair, len(result.GetResults()))
 	for i, m := range result.GetResults() {
-		src := entity.TatoebaSentenceResponse{
-			SentenceNumber: m.GetSrc().GetSentenceNumber(),
-			Lang2:          m.GetSrc().GetLang3().ToLang2().String(),
-			Text:           m.GetSrc().GetText(),
-			Author:         m.GetSrc().GetAuthor(),
-			UpdatedAt:      m.GetSrc().GetUpdatedAt(),
-		}
-		if err := libD.Validator.Struct(src); err != nil {
+		src, err := ToTatoebaSentenceResponse(ctx, m.GetSrc())
+		if err != nil {
 			return nil, err
 		}
 
-		dst := entity.TatoebaSentenceResponse{
-			SentenceNumber: m.GetDst().GetSentenceNumber(),
-			Lang2:          m.GetDst().GetLang3().ToLang2().String(),
-			Text:           m.GetDst().GetText(),
-			Author:         m.GetDst().GetAuthor(),
-			UpdatedAt:      m.GetDst().GetUpdatedAt(),
-		}
-		if err := libD.Validator.Struct(dst); err != nil {
+		dst, err := ToTatoebaSentenceResponse(ctx, m.GetDst())
+		if err != nil {
 			return nil, err
 		}
 
+		var pivot *entity.TatoebaSentenceResponse
+		if !m.IsDirect() {
+			tmpPivot, err := ToTatoebaSentenceResponse(ctx, m.GetPivot())
+			if err != nil {
+				return nil, err
+			}
+			pivot = tmpPivot
+		}
+
 		entities[i] = entity.TatoebaSentencePair{
-			Src: src,
-			Dst: dst,
+			Src:    *src,
+			Dst:    *dst,
+			Direct: m.IsDirect(),
+			Pivot:  pivot,
 		}
 	}
 
+	nextCursor := ""
+	if result.GetNextCursor() != nil {
+		nextCursor = result.GetNextCursor().String()
+	}
+
 	return &entity.TatoebaSentencePairFindResponse{
-		TotalCount: result.GetTotalCount(),
-		Results:    entities,
+		TotalCount:       result.GetTotalCount(),
+		TotalCountCapped: result.IsTotalCountCapped(),
+		Results:          entities,
+		NextCursor:       nextCursor,
 	}, nil
 }
 
 func ToTatoebaSentenceResponse(ctx context.Context, result service.TatoebaSentence) (*entity.TatoebaSentenceResponse, error) {
+	audios := make([]entity.TatoebaAudioResponse, len(result.GetAudios()))
+	for i, audio := range result.GetAudios() {
+		audios[i] = entity.TatoebaAudioResponse{
+			ID:             audio.GetAudioID(),
+			Author:         audio.GetAuthor(),
+			License:        audio.GetLicense(),
+			AttributionURL: audio.GetAttributionURL(),
+		}
+	}
+
 	e := &entity.TatoebaSentenceResponse{
 		SentenceNumber: result.GetSentenceNumber(),
 		Lang2:          result.GetLang3().ToLang2().String(),
+		Lang3:          result.GetLang3().String(),
 		Text:           result.GetText(),
 		Author:         result.GetAuthor(),
 		UpdatedAt:      result.GetUpdatedAt(),
+		Difficulty:     result.GetDifficulty(),
+		License:        string(result.GetLicense()),
+		Attribution:    toAttribution(result),
+		HasAudio:       len(audios) > 0,
+		Audios:         audios,
 	}
 	return e, libD.Validator.Struct(e)
 }
+
+var licenseNames = map[service.TatoebaSentenceLicense]string{
+	service.TatoebaSentenceLicenseCCBY20FR: "CC BY 2.0 FR",
+	service.TatoebaSentenceLicenseCC0:      "CC0 1.0",
+}
+
+// toAttribution returns the credit line which apps display with the sentence, like
+// "Sentence #1234 by bob, Tatoeba (https://tatoeba.org/sentences/show/1234), CC BY 2.0 FR"
+func toAttribution(sentence service.TatoebaSentence) string {
+	source := fmt.Sprintf("Tatoeba (https://tatoeba.org/sentences/show/%d)", sentence.GetSentenceNumber())
+	license := licenseNames[sentence.GetLicense()]
+	if sentence.GetAuthor() == "" {
+		return fmt.Sprintf("Sentence #%d, %s, %s", sentence.GetSentenceNumber(), source, license)
+	}
+	return fmt.Sprintf("Sentence #%d by %s, %s, %s", sentence.GetSentenceNumber(), sentence.GetAuthor(), source, license)
+}
+
+// ToTatoebaSentenceTranslationsResponse groups the translations by language. The translations must be sorted by language
+func ToTatoebaSentenceTranslationsResponse(ctx context.Context, sentence service.TatoebaSentence, translations []service.TatoebaSentencePair) (*entity.TatoebaSentenceTranslationsResponse, error) {
+	sentenceResponse, err := ToTatoebaSentenceResponse(ctx, sentence)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]entity.TatoebaSentenceTranslationGroup, 0)
+	for _, m := range translations {
+		dst, err := ToTatoebaSentenceResponse(ctx, m.GetDst())
+		if err != nil {
+			return nil, err
+		}
+
+		var pivot *entity.TatoebaSentenceResponse
+		if !m.IsDirect() {
+			tmpPivot, err := ToTatoebaSentenceResponse(ctx, m.GetPivot())
+			if err != nil {
+				return nil, err
+			}
+			pivot = tmpPivot
+		}
+
+		if len(groups) == 0 || groups[len(groups)-1].Lang3 != dst.Lang3 {
+			groups = append(groups, entity.TatoebaSentenceTranslationGroup{
+				Lang2:        dst.Lang2,
+				Lang3:        dst.Lang3,
+				Translations: make([]entity.TatoebaSentenceTranslationResponse, 0),
+			})
+		}
+		group := &groups[len(groups)-1]
+		group.Translations = append(group.Translations, entity.TatoebaSentenceTranslationResponse{
+			Sentence: *dst,
+			Direct:   m.IsDirect(),
+			Pivot:    pivot,
+		})
+	}
+
+	return &entity.TatoebaSentenceTranslationsResponse{
+		Sentence: *sentenceResponse,
+		Groups:   groups,
+	}, nil
+}
+
+func ToTatoebaSentenceBatchFindResponse(ctx context.Context, sentences []service.TatoebaSentence, missingSentenceNumbers []int) (*entity.TatoebaSentenceBatchFindResponse, error) {
+	results := make([]entity.TatoebaSentenceResponse, len(sentences))
+	for i, m := range sentences {
+		e, err := ToTatoebaSentenceResponse(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = *e
+	}
+
+	return &entity.TatoebaSentenceBatchFindResponse{
+		Results:                results,
+		MissingSentenceNumbers: missingSentenceNumbers,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package converter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/converter"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/entity"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+func Test_ToTatoebaSentenceSearchCondition_cursor(t *testing.T) {
+	cursor, err := service.NewTatoebaSentencePairCursor(1, 2)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		param   entity.TatoebaSentenceFindParameter
+		wantErr bool
+	}{
+		{
+			name:    "cursor with sentenceNumber",
+			param:   entity.TatoebaSentenceFindParameter{PageSize: 10, Cursor: cursor.String()},
+			wantErr: false,
+		},
+		{
+			name:    "cursor with difficulty",
+			param:   entity.TatoebaSentenceFindParameter{PageSize: 10, Cursor: cursor.String(), SortBy: "difficulty"},
+			wantErr: true,
+		},
+		{
+			name:    "cursor with random",
+			param:   entity.TatoebaSentenceFindParameter{PageSize: 10, Cursor: cursor.String(), Random: true},
+			wantErr: true,
+		},
+		{
+			name:    "random without cursor",
+			param:   entity.TatoebaSentenceFindParameter{PageNo: 2, PageSize: 10, Random: true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := tt.param
+			cond, err := converter.ToTatoebaSentenceSearchCondition(context.Background(), &param)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, libD.ErrInvalidArgument))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.param.Cursor != "", cond.GetCursor() != nil)
+		})
+	}
+}
+
+func Test_ToTatoebaSentenceSearchCondition_invalidArgument(t *testing.T) {
+	tests := []struct {
+		name        string
+		param       entity.TatoebaSentenceFindParameter
+		wantMessage string
+	}{
+		{
+			name:        "invalid srcLang3",
+			param:       entity.TatoebaSentenceFindParameter{PageSize: 10, SrcLang3: "en"},
+			wantMessage: "invalid srcLang3. invalid parameter. Lang3: en",
+		},
+		{
+			name:        "invalid pageSize",
+			param:       entity.TatoebaSentenceFindParameter{PageNo: 1, PageSize: 0},
+			wantMessage: "invalid parameter. pageSize must satisfy required",
+		},
+		{
+			name:        "cursor with random",
+			param:       entity.TatoebaSentenceFindParameter{PageSize: 10, Cursor: "MSwy", Random: true},
+			wantMessage: "invalid cursor. it is not available with random",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := tt.param
+			_, err := converter.ToTatoebaSentenceSearchCondition(context.Background(), &param)
+			assert.True(t, errors.Is(err, libD.ErrInvalidArgument))
+
+			// the message describes the argument for the response
+			invalidArgument := &libD.InvalidArgumentError{}
+			require.True(t, errors.As(err, &invalidArgument))
+			assert.Equal(t, tt.wantMessage, invalidArgument.Error())
+		})
+	}
+}
+
+func Test_ToTatoebaSentenceResponse_attribution(t *testing.T) {
+	lang3, err := domain.NewLang3("eng")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		author  string
+		license service.TatoebaSentenceLicense
+		want    string
+	}{
+		{
+			name:    "CC BY 2.0 FR",
+			author:  "bob",
+			license: service.TatoebaSentenceLicenseCCBY20FR,
+			want:    "Sentence #1234 by bob, Tatoeba (https://tatoeba.org/sentences/show/1234), CC BY 2.0 FR",
+		},
+		{
+			name:    "CC0 1.0",
+			author:  "bob",
+			license: service.TatoebaSentenceLicenseCC0,
+			want:    "Sentence #1234 by bob, Tatoeba (https://tatoeba.org/sentences/show/1234), CC0 1.0",
+		},
+		{
+			name:    "empty author",
+			author:  "",
+			license: service.TatoebaSentenceLicenseCCBY20FR,
+			want:    "Sentence #1234, Tatoeba (https://tatoeba.org/sentences/show/1234), CC BY 2.0 FR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentence, err := service.NewTatoebaSentence(1234, lang3, "Hello.", tt.author, time.Now(), 0, 0, tt.license, nil)
+			require.NoError(t, err)
+
+			response, err := converter.ToTatoebaSentenceResponse(context.Background(), sentence)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, response.Attribution)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package entity
+
+type ErrorResponse struct {
+	// Code is one of invalid_argument, not_found, conflict and internal_error
+	Code string `json:"code"`
+	// Message describes the invalid argument, such as the field and the rule it breaks, if the code is invalid_argument.
+	// Otherwise it is the fixed description of the code. The details are logged with the request ID
+	Message string `json:"message"`
+	// RequestID is the ID of the request in the logs
+	RequestID string `json:"requestId"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type ImportJobStartResponse struct {
+	JobID int `json:"jobId"`
+}
+
+type ImportJobResponse struct {
+	ID int `json:"id"`
+	// JobType is one of sentence, link, tag, audio and license
+	JobType string `json:"jobType"`
+	// Mode is one of insert and sync
+	Mode string `json:"mode"`
+	// DryRun is true if the job did not write the rows. Its counts are the numbers of the rows which would be written
+	DryRun bool `json:"dryRun"`
+	// Status is one of running, succeeded, failed and cancelled. A job whose process stopped fails with the error message interrupted
+	Status    string `json:"status"`
+	ReadCount int    `json:"readCount"`
+	// ImportCount is the number of inserted rows
+	ImportCount int `json:"importCount"`
+	UpdateCount int `json:"updateCount"`
+	DeleteCount int `json:"deleteCount"`
+	SkipCount   int `json:"skipCount"`
+	// RejectCount is the number of rejected rows, which are malformed or have texts longer than the limit. They are included in SkipCount
+	RejectCount int `json:"rejectCount"`
+	// RejectedRowsPath is the path to download the rejected rows report. It is set when some rows are rejected
+	RejectedRowsPath string     `json:"rejectedRowsPath,omitempty"`
+	ErrorMessage     string     `json:"errorMessage,omitempty"`
+	CancelRequested  bool       `json:"cancelRequested"`
+	StartedAt        time.Time  `json:"startedAt"`
+	FinishedAt       *time.Time `json:"finishedAt,omitempty"`
+	FileChecksum     string     `json:"fileChecksum"`
+	// Languages are the imported languages. Empty means all languages
+	Languages []string `json:"languages"`
+	// ResumedFromJobID is the job from whose checkpoint the job resumed
+	ResumedFromJobID *int `json:"resumedFromJobId,omitempty"`
+}
@@ -3,26 +3,131 @@ package entity
 import "time"
 
 type TatoebaSentenceFindParameter struct {
-	PageNo   int    `json:"pageNo" binding:"required,gte=1"`
+	PageNo   int    `json:"pageNo" binding:"required_without=Cursor,omitempty,gte=1"`
 	PageSize int    `json:"pageSize" binding:"required,gte=1"`
 	Keyword  string `json:"keyword"`
-	Random   bool   `json:"random"`
+	// DstKeyword is the keyword for the destination sentence. It is required if keywordTarget is both
+	DstKeyword string `json:"dstKeyword"`
+	// MatchMode is one of substring, word, phrase and prefix. The default is substring.
+	// Words of languages written without spaces, like Japanese and Chinese, are matched as substrings in word and prefix modes.
+	// A keyword without letters or digits matches nothing
+	MatchMode string `json:"matchMode" binding:"omitempty,oneof=substring word phrase prefix"`
+	// KeywordTarget is one of src, dst, either and both. The default is src
+	KeywordTarget string `json:"keywordTarget" binding:"omitempty,oneof=src dst either both"`
+	Random        bool   `json:"random"`
+	// Seed is the seed of the random order. Each seed shuffles source sentences in a different order. The same seed returns the same order across pages
+	Seed     *int   `json:"seed" binding:"omitempty,gte=0,lte=2147483646"`
+	SrcLang3 string `json:"srcLang3" binding:"omitempty,len=3,lowercase"`
+	DstLang3 string `json:"dstLang3" binding:"omitempty,len=3,lowercase"`
+	// MaxHops is 1 or 2. 2 includes pairs linked through a pivot sentence. The default is 1
+	MaxHops int `json:"maxHops" binding:"omitempty,gte=1,lte=2"`
+	// SrcFilter is the filter of source sentences
+	SrcFilter *TatoebaSentenceFilterParameter `json:"srcFilter"`
+	// DstFilter is the filter of destination sentences
+	DstFilter *TatoebaSentenceFilterParameter `json:"dstFilter"`
+	// SortBy is one of sentenceNumber, updatedAt, textLength and difficulty of the source sentence. The default is sentenceNumber. It is ignored if random is true
+	SortBy string `json:"sortBy" binding:"omitempty,oneof=sentenceNumber updatedAt textLength difficulty"`
+	// SortOrder is one of asc and desc. The default is asc
+	SortOrder string `json:"sortOrder" binding:"omitempty,oneof=asc desc"`
+	// Cursor is nextCursor of the previous response. pageNo is ignored if it is specified. It is available only if sortBy is sentenceNumber and random is false
+	Cursor string `json:"cursor"`
+}
+
+type TatoebaSentenceFilterParameter struct {
+	// MinDifficulty is the minimum difficulty from 0 (easiest) to 100 (hardest)
+	MinDifficulty *int `json:"minDifficulty" binding:"omitempty,gte=0,lte=100"`
+	// MaxDifficulty is the maximum difficulty from 0 (easiest) to 100 (hardest)
+	MaxDifficulty *int `json:"maxDifficulty" binding:"omitempty,gte=0,lte=100"`
+	// MinLength is the minimum number of characters
+	MinLength int `json:"minLength" binding:"gte=0"`
+	// MaxLength is the maximum number of characters. 0 means no limit
+	MaxLength int `json:"maxLength" binding:"omitempty,gtefield=MinLength"`
+	// MinWordCount is the minimum number of words. Each character of languages written without spaces is counted as a word
+	MinWordCount int `json:"minWordCount" binding:"gte=0"`
+	// MaxWordCount is the maximum number of words. 0 means no limit
+	MaxWordCount int `json:"maxWordCount" binding:"omitempty,gtefield=MinWordCount"`
+	// IncludeAuthors restricts sentences to those written by one of the authors
+	IncludeAuthors []string `json:"includeAuthors" binding:"omitempty,dive,required,max=20"`
+	// ExcludeAuthors excludes sentences written by the authors
+	ExcludeAuthors []string `json:"excludeAuthors" binding:"omitempty,dive,required,max=20"`
+	// IncludeTags restricts sentences to those with one of the tags
+	IncludeTags []string `json:"includeTags" binding:"omitempty,dive,required,max=100"`
+	// ExcludeTags excludes sentences with the tags, like "@needs native check"
+	ExcludeTags []string `json:"excludeTags" binding:"omitempty,dive,required,max=100"`
+	// WithAudioOnly restricts sentences to those with a recording by a native speaker
+	WithAudioOnly bool `json:"withAudioOnly"`
+	// License is one of CC-BY-2.0-FR and CC0-1.0. All licenses if empty
+	License string `json:"license" binding:"omitempty,oneof=CC-BY-2.0-FR CC0-1.0"`
+	// UpdatedAfter restricts sentences to those updated after the time
+	UpdatedAfter *time.Time `json:"updatedAfter"`
+	// UpdatedBefore restricts sentences to those updated before the time
+	UpdatedBefore *time.Time `json:"updatedBefore"`
 }
 
 type TatoebaSentenceResponse struct {
 	SentenceNumber int       `json:"sentenceNumber"`
-	Lang2          string    `json:"lang2" binding:"len=2" validate:"oneof=ja en"`
+	Lang2          string    `json:"lang2" binding:"len=2" validate:"len=2"`
+	Lang3          string    `json:"lang3" validate:"len=3"`
 	Text           string    `json:"text"`
 	Author         string    `json:"author"`
 	UpdatedAt      time.Time `json:"updatedAt"`
+	Difficulty     int       `json:"difficulty"`
+	// License is the license of the sentence, CC-BY-2.0-FR or CC0-1.0
+	License string `json:"license"`
+	// Attribution is the credit line to display with the sentence
+	Attribution string `json:"attribution"`
+	HasAudio    bool   `json:"hasAudio"`
+	// Audios are the recordings of the sentence by native speakers
+	Audios []TatoebaAudioResponse `json:"audios"`
+}
+
+type TatoebaAudioResponse struct {
+	ID int `json:"id"`
+	// Author is the username of the speaker
+	Author string `json:"author"`
+	// License is the license of the recording. Empty means that the recording cannot be reused
+	License string `json:"license"`
+	// AttributionURL is the page to credit the speaker. Empty means the profile of the speaker on Tatoeba
+	AttributionURL string `json:"attributionUrl"`
 }
 
 type TatoebaSentencePair struct {
-	Src TatoebaSentenceResponse `json:"src"`
-	Dst TatoebaSentenceResponse `json:"dst"`
+	Src    TatoebaSentenceResponse  `json:"src"`
+	Dst    TatoebaSentenceResponse  `json:"dst"`
+	Direct bool                     `json:"direct"`
+	Pivot  *TatoebaSentenceResponse `json:"pivot,omitempty"`
 }
 
 type TatoebaSentencePairFindResponse struct {
-	TotalCount int                   `json:"totalCount"`
-	Results    []TatoebaSentencePair `json:"results"`
+	TotalCount       int                   `json:"totalCount"`
+	TotalCountCapped bool                  `json:"totalCountCapped"`
+	Results          []TatoebaSentencePair `json:"results"`
+	NextCursor       string                `json:"nextCursor,omitempty"`
+	Seed             *int                  `json:"seed,omitempty"`
+}
+
+type TatoebaSentenceTranslationResponse struct {
+	Sentence TatoebaSentenceResponse  `json:"sentence"`
+	Direct   bool                     `json:"direct"`
+	Pivot    *TatoebaSentenceResponse `json:"pivot,omitempty"`
+}
+
+type TatoebaSentenceTranslationGroup struct {
+	Lang2        string                               `json:"lang2"`
+	Lang3        string                               `json:"lang3"`
+	Translations []TatoebaSentenceTranslationResponse `json:"translations"`
+}
+
+type TatoebaSentenceTranslationsResponse struct {
+	Sentence TatoebaSentenceResponse           `json:"sentence"`
+	Groups   []TatoebaSentenceTranslationGroup `json:"groups"`
+}
+
+type TatoebaSentenceBatchFindParameter struct {
+	SentenceNumbers []int `json:"sentenceNumbers" binding:"required,min=1,max=500,dive,gte=1"`
+}
+
+type TatoebaSentenceBatchFindResponse struct {
+	Results                []TatoebaSentenceResponse `json:"results"`
+	MissingSentenceNumbers []int                     `json:"missingSentenceNumbers"`
 }
@@ -20,6 +20,7 @@ func Test_TatoebaSentenceResponse_validation(t *testing.T) {
 			name: "lang2 is 'en'",
 			entity: entity.TatoebaSentenceResponse{
 				Lang2: "en",
+				Lang3: "eng",
 			},
 			wantErr: false,
 		},
@@ -27,6 +28,7 @@ func Test_TatoebaSentenceResponse_validation(t *testing.T) {
 			name: "lang2 is 'ja'",
 			entity: entity.TatoebaSentenceResponse{
 				Lang2: "ja",
+				Lang3: "jpn",
 			},
 			wantErr: false,
 		},
@@ -34,9 +36,18 @@ func Test_TatoebaSentenceResponse_validation(t *testing.T) {
 			name: "lang2 is 'es'",
 			entity: entity.TatoebaSentenceResponse{
 				Lang2: "es",
+				Lang3: "spa",
+			},
+			wantErr: false,
+		},
+		{
+			name: "lang2 is 'eng'",
+			entity: entity.TatoebaSentenceResponse{
+				Lang2: "eng",
+				Lang3: "eng",
 			},
 			wantErr:    true,
-			wantErrMsg: "Key: 'TatoebaSentenceResponse.Lang2' Error:Field validation for 'Lang2' failed on the 'oneof' tag",
+			wantErrMsg: "Key: 'TatoebaSentenceResponse.Lang2' Error:Field validation for 'Lang2' failed on the 'len' tag",
 		},
 	}
 	for _, tt := range tests {
@@ -44,6 +55,8 @@ func Test_TatoebaSentenceResponse_validation(t *testing.T) {
 			err := libD.Validator.Struct(tt.entity)
 			if tt.wantErr {
 				assert.Equal(t, err.Error(), tt.wantErrMsg)
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
@@ -1,15 +1,87 @@
 package handlerhelper
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/entity"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+const (
+	ErrorCodeInvalidArgument = "invalid_argument"
+	ErrorCodeNotFound        = "not_found"
+	ErrorCodeConflict        = "conflict"
+	ErrorCodeInternalError   = "internal_error"
 )
 
+// HandleFunction calls fn and writes the error response if fn returns an error which errorHandle does not handle
 func HandleFunction(c *gin.Context, fn func() error, errorHandle func(c *gin.Context, err error) bool) {
 	if err := fn(); err != nil {
 		if handled := errorHandle(c, err); !handled {
-			c.Status(http.StatusInternalServerError)
+			status, response := toErrorResponse(err)
+			response.RequestID = requestID(c)
+			c.JSON(status, response)
+		}
+	}
+}
+
+// toErrorResponse returns the status and the message of the error. The message of a bad request describes the invalid argument.
+// The other errors have fixed messages because they may contain internal details, which are logged with the request ID instead.
+// Only errors wrapping ErrInvalidArgument, such as binding errors of requests, are bad requests. Other validation errors are internal errors
+func toErrorResponse(err error) (int, entity.ErrorResponse) {
+	switch {
+	case errors.Is(err, service.ErrTatoebaSentenceNotFound):
+		return http.StatusNotFound, entity.ErrorResponse{
+			Code:    ErrorCodeNotFound,
+			Message: "sentence not found",
+		}
+	case errors.Is(err, service.ErrImportJobNotFound):
+		return http.StatusNotFound, entity.ErrorResponse{
+			Code:    ErrorCodeNotFound,
+			Message: "import job not found",
+		}
+	case errors.Is(err, service.ErrImportJobAlreadyFinished):
+		return http.StatusConflict, entity.ErrorResponse{
+			Code:    ErrorCodeConflict,
+			Message: "import job already finished",
 		}
+	case errors.Is(err, service.ErrImportJobAlreadyRunning):
+		return http.StatusConflict, entity.ErrorResponse{
+			Code:    ErrorCodeConflict,
+			Message: "import job of the same file already running",
+		}
+	case errors.Is(err, libD.ErrInvalidArgument):
+		return http.StatusBadRequest, entity.ErrorResponse{
+			Code:    ErrorCodeInvalidArgument,
+			Message: invalidArgumentMessage(err),
+		}
+	default:
+		return http.StatusInternalServerError, entity.ErrorResponse{
+			Code:    ErrorCodeInternalError,
+			Message: http.StatusText(http.StatusInternalServerError),
+		}
+	}
+}
+
+// invalidArgumentMessage returns the message of InvalidArgumentError in the error. Other errors may contain internal details, so their message is fixed
+func invalidArgumentMessage(err error) string {
+	var invalidArgument *libD.InvalidArgumentError
+	if errors.As(err, &invalidArgument) {
+		return invalidArgument.Error()
+	}
+	return libD.ErrInvalidArgument.Error()
+}
+
+// requestID returns the trace ID, which is logged as request_id by the trace log middleware
+func requestID(c *gin.Context) string {
+	sc := trace.SpanFromContext(c.Request.Context()).SpanContext()
+	if !sc.TraceID().IsValid() {
+		return ""
 	}
+	return sc.TraceID().String()
 }
@@ -0,0 +1,105 @@
+package handlerhelper_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/entity"
+	handlerhelper "github.com/kujilabo/cocotola-tatoeba-api/src/app/controller/helper"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
+)
+
+type testParameter struct {
+	PageSize int `validate:"gte=1"`
+}
+
+func Test_HandleFunction(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name:       "sentence not found",
+			err:        liberrors.Errorf("find. err: %w", service.ErrTatoebaSentenceNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   handlerhelper.ErrorCodeNotFound,
+		},
+		{
+			name:       "import job already running",
+			err:        liberrors.Errorf("start. err: %w", service.ErrImportJobAlreadyRunning),
+			wantStatus: http.StatusConflict,
+			wantCode:   handlerhelper.ErrorCodeConflict,
+		},
+		{
+			name:        "invalid argument",
+			err:         liberrors.Errorf("invalid parameter. secret detail. err: %w", libD.ErrInvalidArgument),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    handlerhelper.ErrorCodeInvalidArgument,
+			wantMessage: "invalid argument",
+		},
+		{
+			name:        "invalid argument with the detail",
+			err:         liberrors.Errorf("invalid cursor. err: %w", libD.NewInvalidArgumentError("cursor", "it is not available with random")),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    handlerhelper.ErrorCodeInvalidArgument,
+			wantMessage: "invalid cursor. it is not available with random",
+		},
+		{
+			name:        "validation error of the request",
+			err:         libD.ToInvalidArgumentError("parameter", libD.Validator.Struct(&testParameter{PageSize: 0})),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    handlerhelper.ErrorCodeInvalidArgument,
+			wantMessage: "invalid parameter. pageSize must satisfy gte=1",
+		},
+		{
+			name:        "validation error of stored data",
+			err:         liberrors.Errorf("new. err: %w", libD.Validator.Var(0, "gte=1")),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    handlerhelper.ErrorCodeInternalError,
+			wantMessage: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name:        "other error",
+			err:         errors.New("failed"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    handlerhelper.ErrorCodeInternalError,
+			wantMessage: http.StatusText(http.StatusInternalServerError),
+		},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+			handlerhelper.HandleFunction(c, func() error {
+				return tt.err
+			}, func(c *gin.Context, err error) bool {
+				return false
+			})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			response := entity.ErrorResponse{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			fields := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &fields))
+			assert.Contains(t, fields, "requestId")
+			assert.Equal(t, tt.wantCode, response.Code)
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, response.Message)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -19,6 +20,10 @@ type UserHandler interface {
 	FindSentencePairs(c *gin.Context)
 
 	FindSentenceBySentenceNumber(c *gin.Context)
+
+	FindSentencesBySentenceNumbers(c *gin.Context)
+
+	FindTranslationsBySentenceNumber(c *gin.Context)
 }
 
 type userHandler struct {
@@ -39,8 +44,9 @@ func NewUserHandler(userUsecase usecase.UserUsecase) UserHandler {
 // @Produce     json
 // @Param       param body entity.TatoebaSentenceFindParameter true "parameter to find sentences"
 // @Success     200 {object} entity.TatoebaSentencePairFindResponse
-// @Failure     400
+// @Failure     400 {object} entity.ErrorResponse
 // @Failure     401
+// @Failure     500 {object} entity.ErrorResponse
 // @Router      /v1/user/sentence_pair/find [post]
 // @Security    BasicAuth
 func (h *userHandler) FindSentencePairs(c *gin.Context) {
@@ -50,8 +56,7 @@ func (h *userHandler) FindSentencePairs(c *gin.Context) {
 	handlerhelper.HandleFunction(c, func() error {
 		param := entity.TatoebaSentenceFindParameter{}
 		if err := c.ShouldBindJSON(&param); err != nil {
-			c.Status(http.StatusBadRequest)
-			return nil
+			return libD.ToInvalidArgumentError("parameter", err)
 		}
 		logger.Debugf("FindSentencePairs. param: %+v", param)
 		parameter, err := converter.ToTatoebaSentenceSearchCondition(ctx, &param)
@@ -66,6 +71,10 @@ func (h *userHandler) FindSentencePairs(c *gin.Context) {
 		if err != nil {
 			return liberrors.Errorf("convert result to TatoebaSentenceFindResponse. err: %w", err)
 		}
+		if parameter.IsRandom() {
+			seed := parameter.GetSeed()
+			response.Seed = &seed
+		}
 
 		c.JSON(http.StatusOK, response)
 		return nil
@@ -80,8 +89,10 @@ func (h *userHandler) FindSentencePairs(c *gin.Context) {
 // @Produce     json
 // @Param       sentenceNumber path int true "Sentence number"
 // @Success     200 {object} entity.TatoebaSentenceResponse
-// @Failure     400
+// @Failure     400 {object} entity.ErrorResponse
 // @Failure     401
+// @Failure     404 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
 // @Router      /v1/user/sentence/{sentenceNumber} [get]
 // @Security    BasicAuth
 func (h *userHandler) FindSentenceBySentenceNumber(c *gin.Context) {
@@ -89,7 +100,7 @@ func (h *userHandler) FindSentenceBySentenceNumber(c *gin.Context) {
 	handlerhelper.HandleFunction(c, func() error {
 		sentenceNumber, err := helper.GetIntFromPath(c, "sentenceNumber")
 		if err != nil {
-			return libD.ErrInvalidArgument
+			return libD.ToInvalidArgumentError("sentenceNumber", err)
 		}
 
 		result, err := h.userUsecase.FindSentenceBySentenceNumber(ctx, sentenceNumber)
@@ -106,6 +117,86 @@ func (h *userHandler) FindSentenceBySentenceNumber(c *gin.Context) {
 	}, h.errorHandle)
 }
 
+// FindSentencesBySentenceNumbers godoc
+// @Summary     find sentences by sentence numbers
+// @Description find sentences by up to 500 sentence numbers and return the sentence numbers which are not found
+// @Tags        tatoeba
+// @Accept      json
+// @Produce     json
+// @Param       param body entity.TatoebaSentenceBatchFindParameter true "parameter to find sentences"
+// @Success     200 {object} entity.TatoebaSentenceBatchFindResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/user/sentence/find_by_numbers [post]
+// @Security    BasicAuth
+func (h *userHandler) FindSentencesBySentenceNumbers(c *gin.Context) {
+	ctx := c.Request.Context()
+	handlerhelper.HandleFunction(c, func() error {
+		param := entity.TatoebaSentenceBatchFindParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			return libD.ToInvalidArgumentError("parameter", err)
+		}
+
+		sentences, missing, err := h.userUsecase.FindSentencesBySentenceNumbers(ctx, param.SentenceNumbers)
+		if err != nil {
+			return liberrors.Errorf("execute FindSentencesBySentenceNumbers. err: %w", err)
+		}
+		response, err := converter.ToTatoebaSentenceBatchFindResponse(ctx, sentences, missing)
+		if err != nil {
+			return liberrors.Errorf("convert result to TatoebaSentenceBatchFindResponse. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, response)
+		return nil
+	}, h.errorHandle)
+}
+
+// FindTranslationsBySentenceNumber godoc
+// @Summary     find translations of the sentence
+// @Description find the sentence and its translations grouped by language
+// @Tags        tatoeba
+// @Produce     json
+// @Param       sentenceNumber  path  int  true  "Sentence number"
+// @Param       includeIndirect query bool false "include translations linked through a pivot sentence"
+// @Success     200 {object} entity.TatoebaSentenceTranslationsResponse
+// @Failure     400 {object} entity.ErrorResponse
+// @Failure     401
+// @Failure     404 {object} entity.ErrorResponse
+// @Failure     500 {object} entity.ErrorResponse
+// @Router      /v1/user/sentence/{sentenceNumber}/translations [get]
+// @Security    BasicAuth
+func (h *userHandler) FindTranslationsBySentenceNumber(c *gin.Context) {
+	ctx := c.Request.Context()
+	handlerhelper.HandleFunction(c, func() error {
+		sentenceNumber, err := helper.GetIntFromPath(c, "sentenceNumber")
+		if err != nil {
+			return libD.ToInvalidArgumentError("sentenceNumber", err)
+		}
+
+		includeIndirect := false
+		if value := helper.GetStringFromQuery(c, "includeIndirect"); value != "" {
+			tmpIncludeIndirect, err := strconv.ParseBool(value)
+			if err != nil {
+				return libD.ToInvalidArgumentError("includeIndirect", err)
+			}
+			includeIndirect = tmpIncludeIndirect
+		}
+
+		sentence, translations, err := h.userUsecase.FindTranslationsBySentenceNumber(ctx, sentenceNumber, includeIndirect)
+		if err != nil {
+			return liberrors.Errorf("execute FindTranslationsBySentenceNumber. err: %w", err)
+		}
+		response, err := converter.ToTatoebaSentenceTranslationsResponse(ctx, sentence, translations)
+		if err != nil {
+			return liberrors.Errorf("convert result to TatoebaSentenceTranslationsResponse. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, response)
+		return nil
+	}, h.errorHandle)
+}
+
 func (h *userHandler) errorHandle(c *gin.Context, err error) bool {
 	ctx := c.Request.Context()
 	logger := log.FromContext(ctx)
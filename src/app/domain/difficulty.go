@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MinDifficulty = 0
+	MaxDifficulty = 100
+
+	difficultyMaxTokens = 25
+	difficultyMaxChars  = 150
+
+	difficultyRankWeight   = 0.5
+	difficultyTokenWeight  = 0.3
+	difficultyLengthWeight = 0.2
+)
+
+// Tokenize splits the text into lower-cased words.
+// Scripts written without spaces such as Han, Hiragana, Katakana and Thai are split into characters.
+func Tokenize(text string) []string {
+	tokens := make([]string, 0)
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '\'':
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// CountWords returns the number of words in the text. Each character of scripts written without spaces is counted as a word
+func CountWords(text string) int {
+	return len(Tokenize(text))
+}
+
+// WordRanks maps a word to its frequency rank. The most frequent word is 1.
+type WordRanks map[string]int
+
+// WordFrequency counts words of sentences in a language
+type WordFrequency struct {
+	counts map[string]int
+}
+
+func NewWordFrequency() *WordFrequency {
+	return &WordFrequency{
+		counts: make(map[string]int),
+	}
+}
+
+func (f *WordFrequency) Add(text string) {
+	for _, token := range Tokenize(text) {
+		f.counts[token]++
+	}
+}
+
+func (f *WordFrequency) Ranks() WordRanks {
+	words := make([]string, 0, len(f.counts))
+	for word := range f.counts {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if f.counts[words[i]] != f.counts[words[j]] {
+			return f.counts[words[i]] > f.counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+
+	ranks := make(WordRanks, len(words))
+	for i, word := range words {
+		ranks[word] = i + 1
+	}
+	return ranks
+}
+
+// ComputeDifficulty scores the text from MinDifficulty (easy) to MaxDifficulty (hard).
+// The score combines how rare the words are, the number of words and the number of characters.
+func ComputeDifficulty(text string, ranks WordRanks) int {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return MinDifficulty
+	}
+
+	// rare words have high ranks. unknown words are treated as the rarest
+	rankScore := 0.0
+	if vocabularySize := len(ranks); vocabularySize > 1 {
+		maxLogRank := math.Log(float64(vocabularySize))
+		for _, token := range tokens {
+			rank, ok := ranks[token]
+			if !ok {
+				rank = vocabularySize
+			}
+			rankScore += math.Log(float64(rank)) / maxLogRank
+		}
+		rankScore /= float64(len(tokens))
+	}
+
+	tokenScore := math.Min(float64(len(tokens)), difficultyMaxTokens) / difficultyMaxTokens
+	lengthScore := math.Min(float64(utf8.RuneCountInString(text)), difficultyMaxChars) / difficultyMaxChars
+
+	score := difficultyRankWeight*rankScore + difficultyTokenWeight*tokenScore + difficultyLengthWeight*lengthScore
+	return int(math.Round(score * MaxDifficulty))
+}
@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+)
+
+func Test_Tokenize(t *testing.T) {
+	assert.Equal(t, []string{"i", "don't", "like", "cats"}, domain.Tokenize("I don't like cats."))
+	assert.Equal(t, []string{"猫", "が", "好", "き"}, domain.Tokenize("猫が好き。"))
+	assert.Equal(t, []string{}, domain.Tokenize("!?"))
+}
+
+func Test_CountWords(t *testing.T) {
+	assert.Equal(t, 4, domain.CountWords("I don't like cats."))
+	assert.Equal(t, 4, domain.CountWords("猫が好き。"))
+}
+
+func Test_ComputeDifficulty(t *testing.T) {
+	frequency := domain.NewWordFrequency()
+	for _, text := range []string{
+		"I like cats.",
+		"I like dogs.",
+		"I like tea.",
+		"You like cats.",
+		"Notwithstanding the inclement weather, the expedition proceeded.",
+	} {
+		frequency.Add(text)
+	}
+	ranks := frequency.Ranks()
+	assert.Equal(t, 1, ranks["like"])
+
+	easy := domain.ComputeDifficulty("I like cats.", ranks)
+	hard := domain.ComputeDifficulty("Notwithstanding the inclement weather, the expedition proceeded.", ranks)
+	assert.Less(t, easy, hard)
+	assert.GreaterOrEqual(t, easy, domain.MinDifficulty)
+	assert.LessOrEqual(t, hard, domain.MaxDifficulty)
+	assert.Equal(t, domain.MinDifficulty, domain.ComputeDifficulty("", ranks))
+}
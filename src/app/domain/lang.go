@@ -5,6 +5,63 @@ import liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
 const Lang2Len = 2
 const Lang3Len = 3
 
+// ISO 639-1 -> ISO 639-3
+var lang2ToLang3Map = map[string]string{
+	"aa": "aar", "ab": "abk", "ae": "ave", "af": "afr", "ak": "aka", "am": "amh", "an": "arg", "ar": "ara",
+	"as": "asm", "av": "ava", "ay": "aym", "az": "aze", "ba": "bak", "be": "bel", "bg": "bul", "bi": "bis",
+	"bm": "bam", "bn": "ben", "bo": "bod", "br": "bre", "bs": "bos", "ca": "cat", "ce": "che", "ch": "cha",
+	"co": "cos", "cr": "cre", "cs": "ces", "cu": "chu", "cv": "chv", "cy": "cym", "da": "dan", "de": "deu",
+	"dv": "div", "dz": "dzo", "ee": "ewe", "el": "ell", "en": "eng", "eo": "epo", "es": "spa", "et": "est",
+	"eu": "eus", "fa": "fas", "ff": "ful", "fi": "fin", "fj": "fij", "fo": "fao", "fr": "fra", "fy": "fry",
+	"ga": "gle", "gd": "gla", "gl": "glg", "gn": "grn", "gu": "guj", "gv": "glv", "ha": "hau", "he": "heb",
+	"hi": "hin", "ho": "hmo", "hr": "hrv", "ht": "hat", "hu": "hun", "hy": "hye", "hz": "her", "ia": "ina",
+	"id": "ind", "ie": "ile", "ig": "ibo", "ii": "iii", "ik": "ipk", "io": "ido", "is": "isl", "it": "ita",
+	"iu": "iku", "ja": "jpn", "jv": "jav", "ka": "kat", "kg": "kon", "ki": "kik", "kj": "kua", "kk": "kaz",
+	"kl": "kal", "km": "khm", "kn": "kan", "ko": "kor", "kr": "kau", "ks": "kas", "ku": "kur", "kv": "kom",
+	"kw": "cor", "ky": "kir", "la": "lat", "lb": "ltz", "lg": "lug", "li": "lim", "ln": "lin", "lo": "lao",
+	"lt": "lit", "lu": "lub", "lv": "lav", "mg": "mlg", "mh": "mah", "mi": "mri", "mk": "mkd", "ml": "mal",
+	"mn": "mon", "mr": "mar", "ms": "msa", "mt": "mlt", "my": "mya", "na": "nau", "nb": "nob", "nd": "nde",
+	"ne": "nep", "ng": "ndo", "nl": "nld", "nn": "nno", "no": "nor", "nr": "nbl", "nv": "nav", "ny": "nya",
+	"oc": "oci", "oj": "oji", "om": "orm", "or": "ori", "os": "oss", "pa": "pan", "pi": "pli", "pl": "pol",
+	"ps": "pus", "pt": "por", "qu": "que", "rm": "roh", "rn": "run", "ro": "ron", "ru": "rus", "rw": "kin",
+	"sa": "san", "sc": "srd", "sd": "snd", "se": "sme", "sg": "sag", "si": "sin", "sk": "slk", "sl": "slv",
+	"sm": "smo", "sn": "sna", "so": "som", "sq": "sqi", "sr": "srp", "ss": "ssw", "st": "sot", "su": "sun",
+	"sv": "swe", "sw": "swa", "ta": "tam", "te": "tel", "tg": "tgk", "th": "tha", "ti": "tir", "tk": "tuk",
+	"tl": "tgl", "tn": "tsn", "to": "ton", "tr": "tur", "ts": "tso", "tt": "tat", "tw": "twi", "ty": "tah",
+	"ug": "uig", "uk": "ukr", "ur": "urd", "uz": "uzb", "ve": "ven", "vi": "vie", "vo": "vol", "wa": "wln",
+	"wo": "wol", "xh": "xho", "yi": "yid", "yo": "yor", "za": "zha", "zh": "zho", "zu": "zul",
+}
+
+// Tatoeba uses the individual language code for some macrolanguages
+var lang3AliasToLang2Map = map[string]string{
+	"arb": "ar", // Standard Arabic
+	"azj": "az", // North Azerbaijani
+	"cmn": "zh", // Mandarin Chinese
+	"ekk": "et", // Standard Estonian
+	"khk": "mn", // Halh Mongolian
+	"kmr": "ku", // Northern Kurdish
+	"lvs": "lv", // Standard Latvian
+	"npi": "ne", // Nepali (individual language)
+	"ory": "or", // Odia (individual language)
+	"pes": "fa", // Iranian Persian
+	"swh": "sw", // Swahili (individual language)
+	"uzn": "uz", // Northern Uzbek
+	"ydd": "yi", // Eastern Yiddish
+	"zsm": "ms", // Standard Malay
+}
+
+var lang3ToLang2Map map[string]string
+
+func init() {
+	lang3ToLang2Map = make(map[string]string, len(lang2ToLang3Map)+len(lang3AliasToLang2Map))
+	for l2, l3 := range lang2ToLang3Map {
+		lang3ToLang2Map[l3] = l2
+	}
+	for l3, l2 := range lang3AliasToLang2Map {
+		lang3ToLang2Map[l3] = l2
+	}
+}
+
 type Lang2 interface {
 	String() string
 	ToLang3() Lang3
@@ -29,14 +86,10 @@ func (l *lang2) String() string {
 }
 
 func (l *lang2) ToLang3() Lang3 {
-	switch l.value {
-	case "en":
-		return Lang3ENG
-	case "ja":
-		return Lang3JPN
-	default:
-		return Lang3Unknown
+	if v, ok := lang2ToLang3Map[l.value]; ok {
+		return &lang3{value: v}
 	}
+	return Lang3Unknown
 }
 
 type Lang3 interface {
@@ -61,13 +114,10 @@ func NewLang3(lang string) (Lang3, error) {
 func (l *lang3) String() string {
 	return l.value
 }
+
 func (l *lang3) ToLang2() Lang2 {
-	switch l.value {
-	case "eng":
-		return Lang2EN
-	case "jpn":
-		return Lang2JA
-	default:
-		return Lang2Unknown
+	if v, ok := lang3ToLang2Map[l.value]; ok {
+		return &lang2{value: v}
 	}
+	return Lang2Unknown
 }
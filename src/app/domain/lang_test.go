@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+)
+
+func Test_lang3_ToLang2(t *testing.T) {
+	tests := []struct {
+		lang3 string
+		want  string
+	}{
+		{lang3: "eng", want: "en"},
+		{lang3: "jpn", want: "ja"},
+		{lang3: "fra", want: "fr"},
+		{lang3: "deu", want: "de"},
+		{lang3: "kor", want: "ko"},
+		{lang3: "cmn", want: "zh"},
+		{lang3: "tlh", want: "__"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang3, func(t *testing.T) {
+			lang3, err := domain.NewLang3(tt.lang3)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, lang3.ToLang2().String())
+		})
+	}
+}
+
+func Test_lang2_ToLang3(t *testing.T) {
+	tests := []struct {
+		lang2 string
+		want  string
+	}{
+		{lang2: "en", want: "eng"},
+		{lang2: "ja", want: "jpn"},
+		{lang2: "fr", want: "fra"},
+		{lang2: "xx", want: "___"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang2, func(t *testing.T) {
+			lang2, err := domain.NewLang2(tt.lang2)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, lang2.ToLang3().String())
+		})
+	}
+}
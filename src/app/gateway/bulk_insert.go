@@ -0,0 +1,17 @@
+package gateway
+
+import (
+	"gorm.io/gorm/clause"
+)
+
+// bulkInsertSize is the number of rows in a multi-row INSERT, which keeps the placeholders under the limit of SQLite
+const bulkInsertSize = 200
+
+// insertIgnoreClause returns the clause which ignores the rows conflicting with existing ones.
+// MySQL uses INSERT IGNORE and SQLite uses ON CONFLICT DO NOTHING
+func insertIgnoreClause(driverName string) clause.Expression {
+	if driverName == "sqlite3" {
+		return clause.OnConflict{DoNothing: true}
+	}
+	return clause.Insert{Modifier: "IGNORE"}
+}
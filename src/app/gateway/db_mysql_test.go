@@ -35,7 +35,7 @@ func initMySQL() {
 		testDBPort = "3327"
 	}
 
-	testDBURL = fmt.Sprintf("user:password@tcp(%s:%s)/testdb?charset=utf8&parseTime=True&loc=Asia%%2FTokyo", testDBHost, testDBPort)
+	testDBURL = fmt.Sprintf("user:password@tcp(%s:%s)/testdb?charset=utf8&parseTime=True&loc=Asia%%2FTokyo&multiStatements=true", testDBHost, testDBPort)
 
 	fmt.Printf("testDBURL: %s\n", testDBURL)
 
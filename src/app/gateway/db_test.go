@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"testing"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
@@ -16,22 +17,32 @@ import (
 
 func dbList() map[string]*gorm.DB {
 	dbList := make(map[string]*gorm.DB)
-	m, err := openMySQLForTest()
+	if testDBURL != "" {
+		m, err := openMySQLForTest()
+		if err != nil {
+			panic(err)
+		}
+		dbList["mysql"] = m
+	}
+
+	s, err := openSQLiteForTest()
 	if err != nil {
 		panic(err)
 	}
-
-	dbList["mysql"] = m
-
-	// s, err := openSQLiteForTest()
-	// if err != nil {
-	// 	panic(err)
-	// }
-	// dbList["sqlite3"] = s
+	dbList["sqlite3"] = s
 
 	return dbList
 }
 
+// truncateTables removes the rows of all the tables, children first
+func truncateTables(t *testing.T, db *gorm.DB) {
+	for _, table := range []string{"tatoeba_import_rejected_row", "tatoeba_import_job", "tatoeba_audio", "tatoeba_tag", "tatoeba_link", "tatoeba_sentence_pair_count", "tatoeba_sentence"} {
+		if result := db.Exec("DELETE FROM " + table); result.Error != nil {
+			t.Fatalf("failed to truncate %s. err: %v", table, result.Error)
+		}
+	}
+}
+
 func setupDB(db *gorm.DB, driverName string, withInstance func(sqlDB *sql.DB) (database.Driver, error)) {
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+	// tarMagic is at tarMagicOffset of the first header. Both the POSIX "ustar\x00" and the GNU "ustar " formats start with it
+	tarMagic = []byte("ustar")
+)
+
+const (
+	tarMagicOffset = 257
+	// magicPeekSize is large enough to detect all the formats
+	magicPeekSize = 512
+)
+
+// decompressReader detects the compression of the input by its magic bytes on the first Read and decompresses it while reading.
+// gzip and bzip2 streams and tar archives are supported. Only the first regular file of a tar archive is read.
+// Uncompressed input is read as is.
+type decompressReader struct {
+	reader io.Reader
+	opened io.Reader
+	err    error
+}
+
+func newDecompressReader(reader io.Reader) io.Reader {
+	return &decompressReader{
+		reader: reader,
+	}
+}
+
+func (r *decompressReader) Read(p []byte) (int, error) {
+	if r.opened == nil && r.err == nil {
+		r.opened, r.err = openDecompressed(r.reader)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	return r.opened.Read(p)
+}
+
+// openDecompressed unwraps compressions and an archive, which can be nested like .tar.bz2
+func openDecompressed(reader io.Reader) (io.Reader, error) {
+	bufReader := bufio.NewReaderSize(reader, magicPeekSize)
+	magic, err := bufReader.Peek(magicPeekSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, liberrors.Errorf("failed to Peek. err: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzipReader, err := gzip.NewReader(bufReader)
+		if err != nil {
+			return nil, liberrors.Errorf("failed to gzip.NewReader. err: %w", err)
+		}
+		return openDecompressed(gzipReader)
+	case bytes.HasPrefix(magic, bzip2Magic):
+		return openDecompressed(bzip2.NewReader(bufReader))
+	case len(magic) >= tarMagicOffset+len(tarMagic) && bytes.Equal(magic[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic):
+		tarReader := tar.NewReader(bufReader)
+		for {
+			header, err := tarReader.Next()
+			if err != nil {
+				return nil, liberrors.Errorf("failed to find a file in the tar archive. err: %w", err)
+			}
+			if header.Typeflag == tar.TypeReg {
+				return openDecompressed(tarReader)
+			}
+		}
+	default:
+		return bufReader, nil
+	}
+}
@@ -0,0 +1,77 @@
+package gateway_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+)
+
+const decompressTestContent = "1\ta\n2\tb\n"
+
+// decompressTestBzip2 is decompressTestContent compressed by bzip2, which the standard library cannot write
+var decompressTestBzip2 = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x33, 0xe7, 0xb8, 0xf9, 0x00, 0x00,
+	0x02, 0x49, 0x00, 0x00, 0x30, 0x30, 0x00, 0x30, 0x00, 0x20, 0x00, 0x21, 0xa6, 0x99, 0xa0, 0xc0,
+	0x3e, 0x00, 0x85, 0x85, 0xdc, 0x91, 0x4e, 0x14, 0x24, 0x0c, 0xf9, 0xee, 0x3e, 0x40,
+}
+
+func gzipForTest(t *testing.T, content []byte) []byte {
+	buf := bytes.Buffer{}
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// tarForTest archives the content after a directory, which is skipped by the reader
+func tarForTest(t *testing.T, content []byte) []byte {
+	buf := bytes.Buffer{}
+	w := tar.NewWriter(&buf)
+	require.NoError(t, w.WriteHeader(&tar.Header{Name: "tags/", Typeflag: tar.TypeDir, Mode: 0755}))
+	require.NoError(t, w.WriteHeader(&tar.Header{Name: "tags/tags.csv", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+	_, err := w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_decompressReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input func(t *testing.T) []byte
+	}{
+		{name: "uncompressed", input: func(t *testing.T) []byte { return []byte(decompressTestContent) }},
+		{name: "gzip", input: func(t *testing.T) []byte { return gzipForTest(t, []byte(decompressTestContent)) }},
+		{name: "bzip2", input: func(t *testing.T) []byte { return decompressTestBzip2 }},
+		{name: "tar", input: func(t *testing.T) []byte { return tarForTest(t, []byte(decompressTestContent)) }},
+		{name: "tar.gz", input: func(t *testing.T) []byte { return gzipForTest(t, tarForTest(t, []byte(decompressTestContent))) }},
+		{name: "tar.bz2 in tar", input: func(t *testing.T) []byte { return tarForTest(t, decompressTestBzip2) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the input is read through the tag iterator because the reader is not exported
+			iterator := gateway.NewTatoebaTagAddParameterReader(bytes.NewReader(tt.input(t)))
+
+			tagNames := make([]string, 0)
+			for {
+				param, err := iterator.Next(context.Background())
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				tagNames = append(tagNames, param.GetTagName())
+			}
+			assert.Equal(t, []string{"a", "b"}, tagNames)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+type testSentence struct {
+	sentenceNumber int
+	lang3          string
+	text           string
+	author         string
+}
+
+func newLang3(t *testing.T, lang3 string) domain.Lang3 {
+	l, err := domain.NewLang3(lang3)
+	require.NoError(t, err)
+	return l
+}
+
+func newSentenceAddParameter(t *testing.T, s testSentence, updatedAt time.Time) service.TatoebaSentenceAddParameter {
+	author := s.author
+	if author == "" {
+		author = "alice"
+	}
+	param, err := service.NewTatoebaSentenceAddParameter(s.sentenceNumber, newLang3(t, s.lang3), s.text, author, updatedAt)
+	require.NoError(t, err)
+	return param
+}
+
+// addSentences adds the sentences and the links between them in both directions
+func addSentences(t *testing.T, db *gorm.DB, driverName string, sentences []testSentence, links [][2]int) {
+	ctx := context.Background()
+	sentenceRepo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+	require.NoError(t, err)
+	for _, s := range sentences {
+		require.NoError(t, sentenceRepo.Add(ctx, newSentenceAddParameter(t, s, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))))
+	}
+
+	linkRepo, err := gateway.NewTatoebaLinkRepository(db, driverName)
+	require.NoError(t, err)
+	for _, link := range links {
+		for _, pair := range [][2]int{link, {link[1], link[0]}} {
+			param, err := service.NewTatoebaLinkAddParameter(pair[0], pair[1])
+			require.NoError(t, err)
+			require.NoError(t, linkRepo.Add(ctx, param))
+		}
+	}
+	require.NoError(t, linkRepo.RefreshSentencePairCounts(ctx))
+}
+
+// findSrcSentenceNumbers returns the source sentence numbers of the pairs sorted by sentence number
+func findSrcSentenceNumbers(t *testing.T, repo service.TatoebaSentenceRepository, srcLang3, dstLang3 string, keyword service.TatoebaSentenceKeywordCondition, srcFilter service.TatoebaSentenceFilter) []int {
+	cond, err := service.NewTatoebaSentenceSearchCondition(1, 100, keyword, false, 0, newLang3(t, srcLang3), newLang3(t, dstLang3), 1, srcFilter, nil, service.SortBySentenceNumber, service.SortOrderAsc, nil)
+	require.NoError(t, err)
+
+	result, err := repo.FindTatoebaSentencePairs(context.Background(), cond)
+	require.NoError(t, err)
+
+	sentenceNumbers := make([]int, 0)
+	for _, pair := range result.GetResults() {
+		sentenceNumbers = append(sentenceNumbers, pair.GetSrc().GetSentenceNumber())
+	}
+	assert.Equal(t, len(sentenceNumbers), result.GetTotalCount())
+	return sentenceNumbers
+}
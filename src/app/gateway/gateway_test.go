@@ -1,6 +1,18 @@
 package gateway_test
 
-func init() {
-	initMySQL()
+import (
+	"flag"
+	"os"
+	"testing"
+)
+
+// TestMain prepares the databases. MySQL is skipped in the short mode, so that the tests run with SQLite alone
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Short() {
+		initMySQL()
+	}
 	initSQLite()
+
+	os.Exit(m.Run())
 }
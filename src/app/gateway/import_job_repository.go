@@ -0,0 +1,262 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
+)
+
+const maxErrorMessageLength = 1000
+
+type importJobEntity struct {
+	ID               int
+	JobType          string
+	Mode             string
+	Status           string
+	ReadCount        int
+	ImportCount      int
+	UpdateCount      int
+	DeleteCount      int
+	SkipCount        int
+	RejectCount      int
+	ErrorMessage     string
+	CancelRequested  bool
+	StartedAt        time.Time
+	FinishedAt       *time.Time
+	HeartbeatAt      *time.Time
+	FileChecksum     string
+	Languages        string
+	ResumedFromJobID *int
+	DryRun           bool
+}
+
+func (e *importJobEntity) TableName() string {
+	return "tatoeba_import_job"
+}
+
+func (e *importJobEntity) toModel() (service.ImportJob, error) {
+	lang3s := make([]domain.Lang3, 0)
+	if e.Languages != "" {
+		for _, language := range strings.Split(e.Languages, ",") {
+			lang3, err := domain.NewLang3(language)
+			if err != nil {
+				return nil, err
+			}
+			lang3s = append(lang3s, lang3)
+		}
+	}
+
+	option, err := service.NewImportOption(e.FileChecksum, service.ImportMode(e.Mode), lang3s, e.DryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewImportJob(e.ID, service.ImportJobType(e.JobType), option, service.ImportJobStatus(e.Status), e.ReadCount, e.ImportCount, e.UpdateCount, e.DeleteCount, e.SkipCount, e.RejectCount, e.ErrorMessage, e.CancelRequested, e.StartedAt, e.FinishedAt, e.ResumedFromJobID)
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) (service.ImportJobRepository, error) {
+	if db == nil {
+		return nil, libD.ErrInvalidArgument
+	}
+	return &importJobRepository{
+		db: db,
+	}, nil
+}
+
+func (r *importJobRepository) AddImportJob(ctx context.Context, jobType service.ImportJobType, option service.ImportOption, resumedFromJobID *int) (int, error) {
+	languages := make([]string, len(option.GetLang3s()))
+	for i, lang3 := range option.GetLang3s() {
+		languages[i] = lang3.String()
+	}
+
+	now := time.Now()
+	entity := importJobEntity{
+		JobType:          string(jobType),
+		Mode:             string(option.GetMode()),
+		Status:           string(service.ImportJobStatusRunning),
+		StartedAt:        now,
+		HeartbeatAt:      &now,
+		FileChecksum:     option.GetFileChecksum(),
+		Languages:        strings.Join(languages, ","),
+		ResumedFromJobID: resumedFromJobID,
+		DryRun:           option.IsDryRun(),
+	}
+	if result := r.db.Create(&entity); result.Error != nil {
+		return 0, liberrors.Errorf("failed to AddImportJob. err: %w", result.Error)
+	}
+
+	return entity.ID, nil
+}
+
+func (r *importJobRepository) FindImportJob(ctx context.Context, id int) (service.ImportJob, error) {
+	entity := importJobEntity{}
+	if result := r.db.Where("id = ?", id).First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, liberrors.Errorf("id: %d, err: %w", id, service.ErrImportJobNotFound)
+		}
+		return nil, result.Error
+	}
+
+	return entity.toModel()
+}
+
+func (r *importJobRepository) FindLatestImportJobByFileChecksum(ctx context.Context, jobType service.ImportJobType, fileChecksum string) (service.ImportJob, error) {
+	entity := importJobEntity{}
+	if result := r.db.Where("job_type = ? AND file_checksum = ?", string(jobType), fileChecksum).
+		Order("id DESC").First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, liberrors.Errorf("fileChecksum: %s, err: %w", fileChecksum, service.ErrImportJobNotFound)
+		}
+		return nil, result.Error
+	}
+
+	return entity.toModel()
+}
+
+type importRejectedRowEntity struct {
+	JobID     int
+	RowNumber int
+	Code      string
+	Reason    string
+}
+
+func (e *importRejectedRowEntity) TableName() string {
+	return "tatoeba_import_rejected_row"
+}
+
+func (e *importRejectedRowEntity) toModel() (service.ImportRejectedRow, error) {
+	return service.NewImportRejectedRow(e.RowNumber, service.RejectCode(e.Code), e.Reason)
+}
+
+func (r *importJobRepository) UpdateImportJobProgress(ctx context.Context, id, readCount, importCount, updateCount, deleteCount, skipCount, rejectCount int) error {
+	if result := r.db.Model(&importJobEntity{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"read_count":   readCount,
+			"import_count": importCount,
+			"update_count": updateCount,
+			"delete_count": deleteCount,
+			"skip_count":   skipCount,
+			"reject_count": rejectCount,
+		}); result.Error != nil {
+		return liberrors.Errorf("failed to UpdateImportJobProgress. err: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) AddImportJobRejectedRows(ctx context.Context, id int, rows []service.ImportRejectedRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	entities := make([]importRejectedRowEntity, len(rows))
+	for i, row := range rows {
+		entities[i] = importRejectedRowEntity{
+			JobID:     id,
+			RowNumber: row.GetRowNumber(),
+			Code:      string(row.GetCode()),
+			Reason:    truncateRunes(row.GetReason(), maxErrorMessageLength),
+		}
+	}
+
+	if result := r.db.CreateInBatches(&entities, bulkInsertSize); result.Error != nil {
+		return liberrors.Errorf("failed to AddImportJobRejectedRows. err: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) FindImportJobRejectedRows(ctx context.Context, id int) ([]service.ImportRejectedRow, error) {
+	entities := []importRejectedRowEntity{}
+	if result := r.db.Where("job_id = ?", id).Order("`row_number`").Find(&entities); result.Error != nil {
+		return nil, liberrors.Errorf("failed to FindImportJobRejectedRows. err: %w", result.Error)
+	}
+
+	results := make([]service.ImportRejectedRow, len(entities))
+	for i, e := range entities {
+		m, err := e.toModel()
+		if err != nil {
+			return nil, err
+		}
+		results[i] = m
+	}
+
+	return results, nil
+}
+
+func (r *importJobRepository) FinishImportJob(ctx context.Context, id int, status service.ImportJobStatus, errorMessage string) error {
+	errorMessage = truncateRunes(errorMessage, maxErrorMessageLength)
+
+	if result := r.db.Model(&importJobEntity{}).Where("id = ? AND status = ?", id, string(service.ImportJobStatusRunning)).
+		UpdateColumns(map[string]interface{}{
+			"status":        string(status),
+			"error_message": errorMessage,
+			"finished_at":   time.Now(),
+		}); result.Error != nil {
+		return liberrors.Errorf("failed to FinishImportJob. err: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) UpdateImportJobHeartbeat(ctx context.Context, id int) error {
+	if result := r.db.Model(&importJobEntity{}).Where("id = ? AND status = ?", id, string(service.ImportJobStatusRunning)).
+		UpdateColumn("heartbeat_at", time.Now()); result.Error != nil {
+		return liberrors.Errorf("failed to UpdateImportJobHeartbeat. err: %w", result.Error)
+	}
+
+	return nil
+}
+
+// FailExpiredImportJobs also fails the running jobs without heartbeats, which were added before heartbeat_at existed
+func (r *importJobRepository) FailExpiredImportJobs(ctx context.Context, expiredAt time.Time, errorMessage string) (int, error) {
+	result := r.db.Model(&importJobEntity{}).
+		Where("status = ? AND (heartbeat_at IS NULL OR heartbeat_at < ?)", string(service.ImportJobStatusRunning), expiredAt).
+		UpdateColumns(map[string]interface{}{
+			"status":        string(service.ImportJobStatusFailed),
+			"error_message": truncateRunes(errorMessage, maxErrorMessageLength),
+			"finished_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to FailExpiredImportJobs. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func truncateRunes(value string, length int) string {
+	if utf8.RuneCountInString(value) > length {
+		return string([]rune(value)[:length])
+	}
+	return value
+}
+
+func (r *importJobRepository) RequestImportJobCancel(ctx context.Context, id int) error {
+	job, err := r.FindImportJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job.GetStatus() != service.ImportJobStatusRunning {
+		return liberrors.Errorf("id: %d, status: %s, err: %w", id, job.GetStatus(), service.ErrImportJobAlreadyFinished)
+	}
+
+	if result := r.db.Model(&importJobEntity{}).Where("id = ?", id).
+		UpdateColumn("cancel_requested", true); result.Error != nil {
+		return liberrors.Errorf("failed to RequestImportJobCancel. err: %w", result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func Test_importJobRepository_FinishImportJob(t *testing.T) {
+	ctx := context.Background()
+
+	for driverName, db := range dbList() {
+		t.Run(driverName, func(t *testing.T) {
+			truncateTables(t, db)
+			repo, err := gateway.NewImportJobRepository(db)
+			require.NoError(t, err)
+
+			option, err := service.NewImportOption("checksum", service.ImportModeInsert, nil, false)
+			require.NoError(t, err)
+			jobID, err := repo.AddImportJob(ctx, service.ImportJobTypeSentence, option, nil)
+			require.NoError(t, err)
+
+			job, err := repo.FindImportJob(ctx, jobID)
+			require.NoError(t, err)
+			assert.Equal(t, service.ImportJobStatusRunning, job.GetStatus())
+			assert.Nil(t, job.GetFinishedAt())
+
+			require.NoError(t, repo.RequestImportJobCancel(ctx, jobID))
+			require.NoError(t, repo.FinishImportJob(ctx, jobID, service.ImportJobStatusCancelled, ""))
+
+			job, err = repo.FindImportJob(ctx, jobID)
+			require.NoError(t, err)
+			assert.True(t, job.IsCancelRequested())
+			assert.Equal(t, service.ImportJobStatusCancelled, job.GetStatus())
+			assert.NotNil(t, job.GetFinishedAt())
+
+			// a finished job is neither finished again nor cancelled
+			require.NoError(t, repo.FinishImportJob(ctx, jobID, service.ImportJobStatusFailed, "failed"))
+			job, err = repo.FindImportJob(ctx, jobID)
+			require.NoError(t, err)
+			assert.Equal(t, service.ImportJobStatusCancelled, job.GetStatus())
+			assert.ErrorIs(t, repo.RequestImportJobCancel(ctx, jobID), service.ErrImportJobAlreadyFinished)
+
+			_, err = repo.FindImportJob(ctx, jobID+1)
+			assert.ErrorIs(t, err, service.ErrImportJobNotFound)
+		})
+	}
+}
+
+func Test_importJobRepository_FindLatestImportJobByFileChecksum(t *testing.T) {
+	ctx := context.Background()
+
+	for driverName, db := range dbList() {
+		t.Run(driverName, func(t *testing.T) {
+			truncateTables(t, db)
+			repo, err := gateway.NewImportJobRepository(db)
+			require.NoError(t, err)
+
+			option, err := service.NewImportOption("checksum", service.ImportModeSync, []domain.Lang3{newLang3(t, "eng")}, false)
+			require.NoError(t, err)
+
+			_, err = repo.FindLatestImportJobByFileChecksum(ctx, service.ImportJobTypeSentence, "checksum")
+			assert.ErrorIs(t, err, service.ErrImportJobNotFound)
+
+			// the first job fails after its checkpoint and the second job resumes from it
+			failedJobID, err := repo.AddImportJob(ctx, service.ImportJobTypeSentence, option, nil)
+			require.NoError(t, err)
+			require.NoError(t, repo.UpdateImportJobProgress(ctx, failedJobID, 6, 1, 2, 3, 4, 5))
+			require.NoError(t, repo.FinishImportJob(ctx, failedJobID, service.ImportJobStatusFailed, "failed"))
+			jobID, err := repo.AddImportJob(ctx, service.ImportJobTypeSentence, option, &failedJobID)
+			require.NoError(t, err)
+			// the job of another type is not found
+			_, err = repo.AddImportJob(ctx, service.ImportJobTypeTag, option, nil)
+			require.NoError(t, err)
+
+			job, err := repo.FindLatestImportJobByFileChecksum(ctx, service.ImportJobTypeSentence, "checksum")
+			require.NoError(t, err)
+			assert.Equal(t, jobID, job.GetID())
+			require.NotNil(t, job.GetResumedFromJobID())
+			assert.Equal(t, failedJobID, *job.GetResumedFromJobID())
+			assert.True(t, job.GetOption().Equals(option))
+
+			job, err = repo.FindImportJob(ctx, failedJobID)
+			require.NoError(t, err)
+			assert.Equal(t, []int{6, 1, 2, 3, 4, 5}, []int{job.GetReadCount(), job.GetImportCount(), job.GetUpdateCount(), job.GetDeleteCount(), job.GetSkipCount(), job.GetRejectCount()})
+			assert.Equal(t, "failed", job.GetErrorMessage())
+		})
+	}
+}
+
+func Test_importJobRepository_rejectedRows(t *testing.T) {
+	ctx := context.Background()
+
+	newRejectedRow := func(t *testing.T, rowNumber int, code service.RejectCode, reason string) service.ImportRejectedRow {
+		row, err := service.NewImportRejectedRow(rowNumber, code, reason)
+		require.NoError(t, err)
+		return row
+	}
+
+	for driverName, db := range dbList() {
+		t.Run(driverName, func(t *testing.T) {
+			truncateTables(t, db)
+			repo, err := gateway.NewImportJobRepository(db)
+			require.NoError(t, err)
+
+			option, err := service.NewImportOption("checksum", service.ImportModeInsert, nil, false)
+			require.NoError(t, err)
+			jobID, err := repo.AddImportJob(ctx, service.ImportJobTypeSentence, option, nil)
+			require.NoError(t, err)
+			otherJobID, err := repo.AddImportJob(ctx, service.ImportJobTypeTag, option, nil)
+			require.NoError(t, err)
+
+			// the rows are added batch by batch
+			require.NoError(t, repo.AddImportJobRejectedRows(ctx, jobID, []service.ImportRejectedRow{
+				newRejectedRow(t, 3, service.RejectCodeTextTooLong, "text is too long"),
+			}))
+			require.NoError(t, repo.AddImportJobRejectedRows(ctx, jobID, []service.ImportRejectedRow{
+				newRejectedRow(t, 1, service.RejectCodeMalformed, "invalid UTF-8"),
+			}))
+			require.NoError(t, repo.AddImportJobRejectedRows(ctx, jobID, nil))
+
+			rows, err := repo.FindImportJobRejectedRows(ctx, jobID)
+			require.NoError(t, err)
+			require.Len(t, rows, 2)
+			// the rows are sorted by row number
+			assert.Equal(t, 1, rows[0].GetRowNumber())
+			assert.Equal(t, service.RejectCodeMalformed, rows[0].GetCode())
+			assert.Equal(t, "invalid UTF-8", rows[0].GetReason())
+			assert.Equal(t, 3, rows[1].GetRowNumber())
+			assert.Equal(t, service.RejectCodeTextTooLong, rows[1].GetCode())
+
+			rows, err = repo.FindImportJobRejectedRows(ctx, otherJobID)
+			require.NoError(t, err)
+			assert.Empty(t, rows)
+		})
+	}
+}
+
+func Test_importJobRepository_FailExpiredImportJobs(t *testing.T) {
+	ctx := context.Background()
+
+	for driverName, db := range dbList() {
+		t.Run(driverName, func(t *testing.T) {
+			truncateTables(t, db)
+			repo, err := gateway.NewImportJobRepository(db)
+			require.NoError(t, err)
+
+			option, err := service.NewImportOption("", service.ImportModeInsert, nil, false)
+			require.NoError(t, err)
+			jobIDs := make([]int, 3)
+			for i := range jobIDs {
+				jobID, err := repo.AddImportJob(ctx, service.ImportJobTypeTag, option, nil)
+				require.NoError(t, err)
+				jobIDs[i] = jobID
+			}
+			require.NoError(t, repo.FinishImportJob(ctx, jobIDs[2], service.ImportJobStatusSucceeded, ""))
+
+			// the heartbeats of the first job stopped and the second job renews its lease
+			past := time.Now().Add(-time.Hour)
+			require.NoError(t, db.Table("tatoeba_import_job").Where("id IN ?", jobIDs).Update("heartbeat_at", past).Error)
+			require.NoError(t, repo.UpdateImportJobHeartbeat(ctx, jobIDs[1]))
+
+			count, err := repo.FailExpiredImportJobs(ctx, time.Now().Add(-time.Minute), "interrupted")
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			want := []service.ImportJobStatus{service.ImportJobStatusFailed, service.ImportJobStatusRunning, service.ImportJobStatusSucceeded}
+			for i, jobID := range jobIDs {
+				job, err := repo.FindImportJob(ctx, jobID)
+				require.NoError(t, err)
+				assert.Equal(t, want[i], job.GetStatus())
+			}
+
+			job, err := repo.FindImportJob(ctx, jobIDs[0])
+			require.NoError(t, err)
+			assert.Equal(t, "interrupted", job.GetErrorMessage())
+			assert.NotNil(t, job.GetFinishedAt())
+		})
+	}
+}
@@ -21,9 +21,21 @@ func NewRepositoryFactory(ctx context.Context, db *gorm.DB, driverName string) (
 }
 
 func (f *repositoryFactory) NewTatoebaSentenceRepository(ctx context.Context) (service.TatoebaSentenceRepository, error) {
-	return NewTatoebaSentenceRepository(f.db)
+	return NewTatoebaSentenceRepository(f.db, f.driverName)
 }
 
 func (f *repositoryFactory) NewTatoebaLinkRepository(ctx context.Context) (service.TatoebaLinkRepository, error) {
-	return NewTatoebaLinkRepository(f.db)
+	return NewTatoebaLinkRepository(f.db, f.driverName)
+}
+
+func (f *repositoryFactory) NewTatoebaTagRepository(ctx context.Context) (service.TatoebaTagRepository, error) {
+	return NewTatoebaTagRepository(f.db, f.driverName)
+}
+
+func (f *repositoryFactory) NewTatoebaAudioRepository(ctx context.Context) (service.TatoebaAudioRepository, error) {
+	return NewTatoebaAudioRepository(f.db, f.driverName)
+}
+
+func (f *repositoryFactory) NewImportJobRepository(ctx context.Context) (service.ImportJobRepository, error) {
+	return NewImportJobRepository(f.db)
 }
@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+const audioColumnCount = 5
+
+type tatoebaAudioAddParameterReader struct {
+	reader *tsvRowReader
+}
+
+// NewTatoebaAudioAddParameterReader returns the iterator of audios. The input can be compressed with gzip or bzip2 and archived with tar
+func NewTatoebaAudioAddParameterReader(reader io.Reader) service.TatoebaAudioAddParameterIterator {
+	return &tatoebaAudioAddParameterReader{
+		reader: newTSVRowReader(reader, audioColumnCount),
+	}
+}
+
+// Next parses a row of sentences_with_audio.csv, which has the sentence number, the audio ID, the username, the license and the attribution URL
+func (r *tatoebaAudioAddParameterReader) Next(ctx context.Context) (service.TatoebaAudioAddParameter, error) {
+	var param service.TatoebaAudioAddParameter
+	if err := r.reader.next(func(columns []string) error {
+		tmpParam, err := r.parse(columns)
+		param = tmpParam
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return param, nil
+}
+
+func (r *tatoebaAudioAddParameterReader) parse(columns []string) (service.TatoebaAudioAddParameter, error) {
+	sentenceNumber, err := strconv.Atoi(columns[0])
+	if err != nil || sentenceNumber <= 0 {
+		return nil, fmt.Errorf("invalid sentence number. value: %s", truncateRunes(columns[0], maxReasonValueLength))
+	}
+
+	audioID, err := strconv.Atoi(columns[1])
+	if err != nil || audioID <= 0 {
+		return nil, fmt.Errorf("invalid audio id. value: %s", truncateRunes(columns[1], maxReasonValueLength))
+	}
+
+	author := nullableColumn(columns[2])
+	if author == "" {
+		return nil, errors.New("empty username")
+	}
+
+	param, err := service.NewTatoebaAudioAddParameter(audioID, sentenceNumber, author, nullableColumn(columns[3]), nullableColumn(columns[4]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid audio. %v", err)
+	}
+
+	return param, nil
+}
+
+// nullableColumn returns the value of the column. \N, which is NULL in the dumps, is empty
+func nullableColumn(value string) string {
+	if value == "\\N" {
+		return ""
+	}
+	return value
+}
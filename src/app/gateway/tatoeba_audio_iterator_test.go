@@ -0,0 +1,67 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func Test_tatoebaAudioAddParameterReader(t *testing.T) {
+	iterator := gateway.NewTatoebaAudioAddParameterReader(strings.NewReader(
+		"1\t10\talice\tCC BY 4.0\thttps://example.com/alice\n" +
+			"2\t20\tbob\t\\N\t\\N\n"))
+
+	param, err := iterator.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, param.GetSentenceNumber())
+	assert.Equal(t, 10, param.GetAudioID())
+	assert.Equal(t, "alice", param.GetAuthor())
+	assert.Equal(t, "CC BY 4.0", param.GetLicense())
+	assert.Equal(t, "https://example.com/alice", param.GetAttributionURL())
+
+	// \N is empty
+	param, err = iterator.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 20, param.GetAudioID())
+	assert.Equal(t, "", param.GetLicense())
+	assert.Equal(t, "", param.GetAttributionURL())
+
+	_, err = iterator.Next(context.Background())
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+func Test_tatoebaAudioAddParameterReader_rejected(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "too few columns", line: "1\t10\talice\tCC BY 4.0"},
+		{name: "invalid sentence number", line: "x\t10\talice\t\\N\t\\N"},
+		{name: "invalid audio id", line: "1\t0\talice\t\\N\t\\N"},
+		{name: "empty username", line: "1\t10\t\\N\t\\N\t\\N"},
+		{name: "invalid UTF-8", line: "1\t10\t\xff\t\\N\t\\N"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator := gateway.NewTatoebaAudioAddParameterReader(strings.NewReader(tt.line + "\n2\t20\tbob\t\\N\t\\N\n"))
+			_, err := iterator.Next(context.Background())
+			rejected := &service.RejectedRowError{}
+			require.True(t, errors.As(err, &rejected))
+			assert.Equal(t, 1, rejected.RowNumber)
+			assert.Equal(t, service.RejectCodeMalformed, rejected.Code)
+
+			// the iterator continues with the next row
+			param, err := iterator.Next(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 20, param.GetAudioID())
+		})
+	}
+}
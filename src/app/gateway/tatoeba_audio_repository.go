@@ -0,0 +1,249 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
+)
+
+type tatoebaAudioRepository struct {
+	db           *gorm.DB
+	driverName   string
+	sentenceRepo service.TatoebaSentenceRepository
+}
+
+type tatoebaAudioEntity struct {
+	AudioID        int
+	SentenceNumber int
+	Author         string
+	License        string
+	AttributionURL string
+}
+
+func (e *tatoebaAudioEntity) TableName() string {
+	return "tatoeba_audio"
+}
+
+func (e *tatoebaAudioEntity) toModel() (service.TatoebaAudio, error) {
+	return service.NewTatoebaAudio(e.AudioID, e.SentenceNumber, e.Author, e.License, e.AttributionURL)
+}
+
+func toTatoebaAudioEntity(param service.TatoebaAudioAddParameter) tatoebaAudioEntity {
+	return tatoebaAudioEntity{
+		AudioID:        param.GetAudioID(),
+		SentenceNumber: param.GetSentenceNumber(),
+		Author:         param.GetAuthor(),
+		License:        param.GetLicense(),
+		AttributionURL: param.GetAttributionURL(),
+	}
+}
+
+func NewTatoebaAudioRepository(db *gorm.DB, driverName string) (service.TatoebaAudioRepository, error) {
+	sentenceRepo, err := NewTatoebaSentenceRepository(db, driverName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tatoebaAudioRepository{
+		db:           db,
+		driverName:   driverName,
+		sentenceRepo: sentenceRepo,
+	}, nil
+}
+
+func (r *tatoebaAudioRepository) FindTatoebaAudiosBySentenceNumbers(ctx context.Context, sentenceNumbers []int) (map[int][]service.TatoebaAudio, error) {
+	return findTatoebaAudiosBySentenceNumbers(r.db, sentenceNumbers)
+}
+
+// findTatoebaAudiosBySentenceNumbers is shared with tatoebaSentenceRepository, which attaches the audios to the sentences
+func findTatoebaAudiosBySentenceNumbers(db *gorm.DB, sentenceNumbers []int) (map[int][]service.TatoebaAudio, error) {
+	audios := make(map[int][]service.TatoebaAudio)
+	if len(sentenceNumbers) == 0 {
+		return audios, nil
+	}
+
+	entities := []tatoebaAudioEntity{}
+	if result := db.Where("sentence_number IN ?", sentenceNumbers).
+		Order("audio_id").Find(&entities); result.Error != nil {
+		return nil, liberrors.Errorf("failed to find tatoebaAudios. err: %w", result.Error)
+	}
+
+	for _, e := range entities {
+		m, err := e.toModel()
+		if err != nil {
+			return nil, err
+		}
+		audios[e.SentenceNumber] = append(audios[e.SentenceNumber], m)
+	}
+
+	return audios, nil
+}
+
+func (r *tatoebaAudioRepository) FindTatoebaAudios(ctx context.Context, lang3s []domain.Lang3, afterAudioID, limit int) ([]service.TatoebaAudio, error) {
+	db := r.db.Table("tatoeba_audio AS A").Select("A.*").
+		Where("A.audio_id > ?", afterAudioID)
+	if len(lang3s) > 0 {
+		languages := make([]string, len(lang3s))
+		for i, lang3 := range lang3s {
+			languages[i] = lang3.String()
+		}
+		db = db.Joins("INNER JOIN tatoeba_sentence AS S ON S.sentence_number = A.sentence_number").
+			Where("S.lang3 IN ?", languages)
+	}
+
+	entities := []tatoebaAudioEntity{}
+	if result := db.Order("A.audio_id").Limit(limit).Scan(&entities); result.Error != nil {
+		return nil, liberrors.Errorf("failed to FindTatoebaAudios. err: %w", result.Error)
+	}
+
+	results := make([]service.TatoebaAudio, len(entities))
+	for i, e := range entities {
+		m, err := e.toModel()
+		if err != nil {
+			return nil, err
+		}
+		results[i] = m
+	}
+
+	return results, nil
+}
+
+func (r *tatoebaAudioRepository) AddBatch(ctx context.Context, params []service.TatoebaAudioAddParameter, lang3s []domain.Lang3) (int, error) {
+	entities, err := r.recordedEntities(ctx, params, lang3s)
+	if err != nil {
+		return 0, err
+	}
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.Clauses(insertIgnoreClause(r.driverName)).CreateInBatches(&entities, bulkInsertSize)
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to AddBatch tatoebaAudio. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaAudioRepository) CountNewAudios(ctx context.Context, params []service.TatoebaAudioAddParameter, lang3s []domain.Lang3) (int, error) {
+	entities, err := r.recordedEntities(ctx, params, lang3s)
+	if err != nil {
+		return 0, err
+	}
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	audioIDs := make([]int, len(entities))
+	for i, entity := range entities {
+		audioIDs[i] = entity.AudioID
+	}
+
+	var existingAudioIDs []int
+	if result := r.db.Model(&tatoebaAudioEntity{}).Where("audio_id IN ?", audioIDs).
+		Pluck("audio_id", &existingAudioIDs); result.Error != nil {
+		return 0, liberrors.Errorf("failed to find tatoebaAudios. err: %w", result.Error)
+	}
+
+	existing := make(map[int]bool)
+	for _, audioID := range existingAudioIDs {
+		existing[audioID] = true
+	}
+
+	count := 0
+	for _, entity := range entities {
+		if !existing[entity.AudioID] {
+			existing[entity.AudioID] = true
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// recordedEntities returns the audios whose sentences exist and are of lang3s unless lang3s is empty
+func (r *tatoebaAudioRepository) recordedEntities(ctx context.Context, params []service.TatoebaAudioAddParameter, lang3s []domain.Lang3) ([]tatoebaAudioEntity, error) {
+	sentenceNumbers := make([]int, len(params))
+	for i, param := range params {
+		sentenceNumbers[i] = param.GetSentenceNumber()
+	}
+
+	contained, err := r.sentenceRepo.ContainsSentencesBySentenceNumbers(ctx, sentenceNumbers, lang3s)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]tatoebaAudioEntity, 0, len(params))
+	for _, param := range params {
+		if contained[param.GetSentenceNumber()] {
+			entities = append(entities, toTatoebaAudioEntity(param))
+		}
+	}
+
+	return entities, nil
+}
+
+func (r *tatoebaAudioRepository) Sync(ctx context.Context, param service.TatoebaAudioAddParameter) (service.TatoebaAudioSyncResult, error) {
+	syncResult, err := r.PreviewSync(ctx, param)
+	if err != nil {
+		return service.TatoebaAudioUnchanged, err
+	}
+
+	switch syncResult {
+	case service.TatoebaAudioInserted:
+		entity := toTatoebaAudioEntity(param)
+		if result := r.db.Create(&entity); result.Error != nil {
+			return service.TatoebaAudioUnchanged, liberrors.Errorf("failed to add tatoebaAudio. err: %w", result.Error)
+		}
+		return service.TatoebaAudioInserted, nil
+	case service.TatoebaAudioUnchanged:
+		return service.TatoebaAudioUnchanged, nil
+	}
+
+	if result := r.db.Model(&tatoebaAudioEntity{}).Where("audio_id = ?", param.GetAudioID()).
+		UpdateColumns(map[string]interface{}{
+			"sentence_number": param.GetSentenceNumber(),
+			"author":          param.GetAuthor(),
+			"license":         param.GetLicense(),
+			"attribution_url": param.GetAttributionURL(),
+		}); result.Error != nil {
+		return service.TatoebaAudioUnchanged, liberrors.Errorf("failed to update tatoebaAudio. err: %w", result.Error)
+	}
+
+	return service.TatoebaAudioUpdated, nil
+}
+
+func (r *tatoebaAudioRepository) PreviewSync(ctx context.Context, param service.TatoebaAudioAddParameter) (service.TatoebaAudioSyncResult, error) {
+	entity := tatoebaAudioEntity{}
+	if result := r.db.Where("audio_id = ?", param.GetAudioID()).
+		First(&entity); result.Error != nil {
+		if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return service.TatoebaAudioUnchanged, liberrors.Errorf("failed to find tatoebaAudio. err: %w", result.Error)
+		}
+		return service.TatoebaAudioInserted, nil
+	}
+
+	if entity == toTatoebaAudioEntity(param) {
+		return service.TatoebaAudioUnchanged, nil
+	}
+
+	return service.TatoebaAudioUpdated, nil
+}
+
+func (r *tatoebaAudioRepository) RemoveTatoebaAudios(ctx context.Context, audioIDs []int) (int, error) {
+	if len(audioIDs) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.Where("audio_id IN ?", audioIDs).Delete(&tatoebaAudioEntity{})
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to RemoveTatoebaAudios. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
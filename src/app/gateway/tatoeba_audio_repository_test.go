@@ -0,0 +1,81 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func Test_tatoebaAudioRepository_Sync(t *testing.T) {
+	ctx := context.Background()
+
+	newAudioAddParameter := func(t *testing.T, audioID, sentenceNumber int, author string) service.TatoebaAudioAddParameter {
+		param, err := service.NewTatoebaAudioAddParameter(audioID, sentenceNumber, author, "CC BY 4.0", "")
+		require.NoError(t, err)
+		return param
+	}
+
+	tests := []struct {
+		name           string
+		audioID        int
+		sentenceNumber int
+		author         string
+		want           service.TatoebaAudioSyncResult
+		wantAudios     map[int][]int
+	}{
+		{name: "new", audioID: 20, sentenceNumber: 2, author: "bob", want: service.TatoebaAudioInserted, wantAudios: map[int][]int{1: {10}, 2: {20}}},
+		{name: "changed author", audioID: 10, sentenceNumber: 1, author: "bob", want: service.TatoebaAudioUpdated, wantAudios: map[int][]int{1: {10}}},
+		{name: "moved to another sentence", audioID: 10, sentenceNumber: 2, author: "alice", want: service.TatoebaAudioUpdated, wantAudios: map[int][]int{2: {10}}},
+		{name: "unchanged", audioID: 10, sentenceNumber: 1, author: "alice", want: service.TatoebaAudioUnchanged, wantAudios: map[int][]int{1: {10}}},
+	}
+
+	for driverName, db := range dbList() {
+		repo, err := gateway.NewTatoebaAudioRepository(db, driverName)
+		require.NoError(t, err)
+
+		for _, tt := range tests {
+			t.Run(driverName+"/"+tt.name, func(t *testing.T) {
+				for _, dryRun := range []bool{true, false} {
+					truncateTables(t, db)
+					addSentences(t, db, driverName, []testSentence{
+						{sentenceNumber: 1, lang3: "eng", text: "one"},
+						{sentenceNumber: 2, lang3: "eng", text: "two"},
+					}, nil)
+					_, err := repo.AddBatch(ctx, []service.TatoebaAudioAddParameter{newAudioAddParameter(t, 10, 1, "alice")}, nil)
+					require.NoError(t, err)
+
+					sync := repo.Sync
+					if dryRun {
+						sync = repo.PreviewSync
+					}
+					result, err := sync(ctx, newAudioAddParameter(t, tt.audioID, tt.sentenceNumber, tt.author))
+					require.NoError(t, err)
+					assert.Equal(t, tt.want, result)
+
+					// a dry run does not write the audio
+					wantAudios := tt.wantAudios
+					if dryRun {
+						wantAudios = map[int][]int{1: {10}}
+					}
+					audios, err := repo.FindTatoebaAudiosBySentenceNumbers(ctx, []int{1, 2})
+					require.NoError(t, err)
+					audioIDs := make(map[int][]int)
+					for sentenceNumber, sentenceAudios := range audios {
+						for _, audio := range sentenceAudios {
+							audioIDs[sentenceNumber] = append(audioIDs[sentenceNumber], audio.GetAudioID())
+						}
+					}
+					assert.Equal(t, wantAudios, audioIDs)
+					if !dryRun && tt.want != service.TatoebaAudioUnchanged {
+						assert.Equal(t, tt.author, audios[tt.sentenceNumber][0].GetAuthor())
+					}
+				}
+			})
+		}
+	}
+}
@@ -2,56 +2,55 @@ package gateway
 
 import (
 	"context"
-	"encoding/csv"
-	"errors"
+	"fmt"
 	"io"
 	"strconv"
 
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 )
 
+const linkColumnCount = 2
+
 type tatoebaLinkAddParameterReader struct {
-	reader *csv.Reader
-	num    int
+	reader *tsvRowReader
 }
 
+// NewTatoebaLinkAddParameterReader returns the iterator of links. The input can be compressed with gzip or bzip2 and archived with tar
 func NewTatoebaLinkAddParameterReader(reader io.Reader) service.TatoebaLinkAddParameterIterator {
-	csvReader := csv.NewReader(reader)
-	csvReader.Comma = '\t'
-	csvReader.LazyQuotes = true
-
 	return &tatoebaLinkAddParameterReader{
-		reader: csvReader,
-		num:    1,
+		reader: newTSVRowReader(reader, linkColumnCount),
 	}
 }
 
+// Next parses a row of links.csv, which has the sentence numbers of the source and the translation
 func (r *tatoebaLinkAddParameterReader) Next(ctx context.Context) (service.TatoebaLinkAddParameter, error) {
-	var line []string
-	line, err := r.reader.Read()
-	if errors.Is(err, io.EOF) {
+	var param service.TatoebaLinkAddParameter
+	if err := r.reader.next(func(columns []string) error {
+		tmpParam, err := r.parse(columns)
+		param = tmpParam
+		return err
+	}); err != nil {
 		return nil, err
 	}
 
-	if err != nil {
-		return nil, err
-	}
+	return param, nil
+}
 
-	from, err := strconv.Atoi(line[0])
-	if err != nil {
-		return nil, err
+func (r *tatoebaLinkAddParameterReader) parse(columns []string) (service.TatoebaLinkAddParameter, error) {
+	from, err := strconv.Atoi(columns[0])
+	if err != nil || from <= 0 {
+		return nil, fmt.Errorf("invalid source sentence number. value: %s", truncateRunes(columns[0], maxReasonValueLength))
 	}
 
-	to, err := strconv.Atoi(line[1])
-	if err != nil {
-		return nil, err
+	to, err := strconv.Atoi(columns[1])
+	if err != nil || to <= 0 {
+		return nil, fmt.Errorf("invalid translation sentence number. value: %s", truncateRunes(columns[1], maxReasonValueLength))
 	}
 
 	param, err := service.NewTatoebaLinkAddParameter(from, to)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid link. from: %d, to: %d", from, to)
 	}
 
-	r.num++
 	return param, nil
 }
@@ -0,0 +1,60 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func Test_tatoebaLinkAddParameterReader(t *testing.T) {
+	iterator := gateway.NewTatoebaLinkAddParameterReader(strings.NewReader("1\t77\n2\t\"3\n"))
+
+	param, err := iterator.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, param.GetFrom())
+	assert.Equal(t, 77, param.GetTo())
+
+	// a quote is not special in a TSV row
+	_, err = iterator.Next(context.Background())
+	rejected := &service.RejectedRowError{}
+	require.True(t, errors.As(err, &rejected))
+	assert.Equal(t, 2, rejected.RowNumber)
+
+	_, err = iterator.Next(context.Background())
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+func Test_tatoebaLinkAddParameterReader_rejected(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "too few columns", line: "1"},
+		{name: "invalid source sentence number", line: "x\t77"},
+		{name: "invalid translation sentence number", line: "1\t0"},
+		{name: "invalid UTF-8", line: "1\t\xff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator := gateway.NewTatoebaLinkAddParameterReader(strings.NewReader(tt.line + "\n2\t3\n"))
+			_, err := iterator.Next(context.Background())
+			rejected := &service.RejectedRowError{}
+			require.True(t, errors.As(err, &rejected))
+			assert.Equal(t, 1, rejected.RowNumber)
+			assert.Equal(t, service.RejectCodeMalformed, rejected.Code)
+
+			// the iterator continues with the next row
+			param, err := iterator.Next(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 3, param.GetTo())
+		})
+	}
+}
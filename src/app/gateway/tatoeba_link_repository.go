@@ -7,6 +7,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
 	libG "github.com/kujilabo/cocotola-tatoeba-api/src/lib/gateway"
@@ -14,6 +15,7 @@ import (
 
 type tatoebaLinkRepository struct {
 	db           *gorm.DB
+	driverName   string
 	sentenceRepo service.TatoebaSentenceRepository
 }
 
@@ -26,14 +28,15 @@ func (e *tatoebaLinkEntity) TableName() string {
 	return "tatoeba_link"
 }
 
-func NewTatoebaLinkRepository(db *gorm.DB) (service.TatoebaLinkRepository, error) {
-	sentenceRepo, err := NewTatoebaSentenceRepository(db)
+func NewTatoebaLinkRepository(db *gorm.DB, driverName string) (service.TatoebaLinkRepository, error) {
+	sentenceRepo, err := NewTatoebaSentenceRepository(db, driverName)
 	if err != nil {
 		return nil, err
 	}
 
 	return &tatoebaLinkRepository{
 		db:           db,
+		driverName:   driverName,
 		sentenceRepo: sentenceRepo,
 	}, nil
 }
@@ -75,3 +78,148 @@ func (r *tatoebaLinkRepository) Add(ctx context.Context, param service.TatoebaLi
 
 	return nil
 }
+
+func (r *tatoebaLinkRepository) AddBatch(ctx context.Context, params []service.TatoebaLinkAddParameter, lang3s []domain.Lang3) (int, error) {
+	entities, err := r.linkableEntities(ctx, params, lang3s)
+	if err != nil {
+		return 0, err
+	}
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.Clauses(insertIgnoreClause(r.driverName)).CreateInBatches(&entities, bulkInsertSize)
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to AddBatch tatoebaLink. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaLinkRepository) CountNewLinks(ctx context.Context, params []service.TatoebaLinkAddParameter, lang3s []domain.Lang3) (int, error) {
+	entities, err := r.linkableEntities(ctx, params, lang3s)
+	if err != nil {
+		return 0, err
+	}
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	froms := make([]int, len(entities))
+	for i, entity := range entities {
+		froms[i] = entity.From
+	}
+
+	existingEntities := make([]tatoebaLinkEntity, 0)
+	if result := r.db.Where("`from` IN ?", froms).Find(&existingEntities); result.Error != nil {
+		return 0, liberrors.Errorf("failed to find tatoebaLinks. err: %w", result.Error)
+	}
+
+	links := make(map[tatoebaLinkEntity]bool)
+	for _, entity := range existingEntities {
+		links[entity] = true
+	}
+
+	count := 0
+	for _, entity := range entities {
+		if !links[entity] {
+			links[entity] = true
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// linkableEntities returns the links whose sentences exist and are of lang3s unless lang3s is empty
+func (r *tatoebaLinkRepository) linkableEntities(ctx context.Context, params []service.TatoebaLinkAddParameter, lang3s []domain.Lang3) ([]tatoebaLinkEntity, error) {
+	sentenceNumbers := make([]int, 0, len(params)*2)
+	for _, param := range params {
+		sentenceNumbers = append(sentenceNumbers, param.GetFrom(), param.GetTo())
+	}
+
+	contained, err := r.sentenceRepo.ContainsSentencesBySentenceNumbers(ctx, sentenceNumbers, lang3s)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]tatoebaLinkEntity, 0, len(params))
+	for _, param := range params {
+		if contained[param.GetFrom()] && contained[param.GetTo()] {
+			entities = append(entities, tatoebaLinkEntity{
+				From: param.GetFrom(),
+				To:   param.GetTo(),
+			})
+		}
+	}
+
+	return entities, nil
+}
+
+func (r *tatoebaLinkRepository) RemoveLinksByFrom(ctx context.Context, from int, keptTos []int) (int, error) {
+	result := r.linksByFrom(from, keptTos).Delete(&tatoebaLinkEntity{})
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to RemoveLinksByFrom. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaLinkRepository) CountLinksByFrom(ctx context.Context, from int, keptTos []int) (int, error) {
+	var count int64
+	if result := r.linksByFrom(from, keptTos).Model(&tatoebaLinkEntity{}).Count(&count); result.Error != nil {
+		return 0, liberrors.Errorf("failed to CountLinksByFrom. err: %w", result.Error)
+	}
+
+	return int(count), nil
+}
+
+func (r *tatoebaLinkRepository) linksByFrom(from int, keptTos []int) *gorm.DB {
+	db := r.db.Where("`from` = ?", from)
+	if len(keptTos) > 0 {
+		db = db.Where("`to` NOT IN ?", keptTos)
+	}
+	return db
+}
+
+func (r *tatoebaLinkRepository) RemoveLinksByFromRange(ctx context.Context, afterFrom, beforeFrom int) (int, error) {
+	result := r.linksByFromRange(afterFrom, beforeFrom).Delete(&tatoebaLinkEntity{})
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to RemoveLinksByFromRange. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaLinkRepository) CountLinksByFromRange(ctx context.Context, afterFrom, beforeFrom int) (int, error) {
+	var count int64
+	if result := r.linksByFromRange(afterFrom, beforeFrom).Model(&tatoebaLinkEntity{}).Count(&count); result.Error != nil {
+		return 0, liberrors.Errorf("failed to CountLinksByFromRange. err: %w", result.Error)
+	}
+
+	return int(count), nil
+}
+
+func (r *tatoebaLinkRepository) linksByFromRange(afterFrom, beforeFrom int) *gorm.DB {
+	db := r.db.Where("`from` > ?", afterFrom)
+	if beforeFrom > 0 {
+		db = db.Where("`from` < ?", beforeFrom)
+	}
+	return db
+}
+
+func (r *tatoebaLinkRepository) RefreshSentencePairCounts(ctx context.Context) error {
+	if result := r.db.Exec("DELETE FROM tatoeba_sentence_pair_count"); result.Error != nil {
+		return liberrors.Errorf("failed to delete tatoeba_sentence_pair_count. err: %w", result.Error)
+	}
+
+	if result := r.db.Exec("INSERT INTO tatoeba_sentence_pair_count (src_lang3, dst_lang3, pair_count)" +
+		" SELECT T1.lang3, T3.lang3, COUNT(*) FROM tatoeba_sentence AS T1" +
+		" INNER JOIN tatoeba_link AS T2 ON T1.sentence_number = T2.`from`" +
+		" INNER JOIN tatoeba_sentence AS T3 ON T3.sentence_number = T2.`to`" +
+		" GROUP BY T1.lang3, T3.lang3"); result.Error != nil {
+		return liberrors.Errorf("failed to insert tatoeba_sentence_pair_count. err: %w", result.Error)
+	}
+
+	return nil
+}
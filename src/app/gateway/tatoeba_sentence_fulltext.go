@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+// ngramLang3s are the languages written without spaces, and Korean whose particles are attached to words.
+// A word-based tokenizer sees a whole clause of them as one word, so their words are matched by n-grams on MySQL and by trigrams on SQLite.
+// The languages must be the same as the ones of the ngram_text column in the MySQL migration and of the trigram table in the SQLite migration
+var ngramLang3s = map[string]bool{
+	"cmn": true, "jpn": true, "khm": true, "kor": true, "lao": true, "lzh": true,
+	"mya": true, "tha": true, "wuu": true, "yue": true, "zho": true,
+}
+
+// trigramLength is the number of characters of a token of the trigram tokenizer. Shorter terms match no tokens
+const trigramLength = 3
+
+// operators of the MySQL boolean full-text search
+var mysqlBooleanOperatorReplacer = strings.NewReplacer(
+	"+", " ", "-", " ", "<", " ", ">", " ", "(", " ", ")", " ",
+	"~", " ", "*", " ", "\"", " ", "@", " ",
+)
+
+// likeEscapeReplacer escapes the wildcards of LIKE with the backslash
+var likeEscapeReplacer = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// toMySQLBooleanQuery converts the keyword into the query of MATCH ... AGAINST (... IN BOOLEAN MODE). It returns an empty string if the keyword has no terms
+func toMySQLBooleanQuery(keyword string, matchMode service.MatchMode) string {
+	terms := strings.Fields(mysqlBooleanOperatorReplacer.Replace(keyword))
+	if len(terms) == 0 {
+		return ""
+	}
+	switch matchMode {
+	case service.MatchModePhrase:
+		return "\"" + strings.Join(terms, " ") + "\""
+	case service.MatchModePrefix:
+		for i, term := range terms {
+			terms[i] = "+" + term + "*"
+		}
+	default:
+		for i, term := range terms {
+			terms[i] = "+\"" + term + "\""
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+// toFTS5Query converts the keyword into the query of the SQLite FTS5 MATCH operator.
+// Terms without letters and digits are dropped because the tokenizer makes no tokens of them.
+// It returns an empty string if the keyword has no terms, which MATCH rejects as a syntax error
+func toFTS5Query(keyword string, matchMode service.MatchMode) string {
+	terms := make([]string, 0)
+	for _, term := range strings.Fields(keyword) {
+		if strings.IndexFunc(term, isTokenRune) < 0 {
+			continue
+		}
+		terms = append(terms, "\""+strings.ReplaceAll(term, "\"", "\"\"")+"\"")
+	}
+	switch matchMode {
+	case service.MatchModePhrase:
+		return strings.Join(terms, " + ")
+	case service.MatchModePrefix:
+		for i, term := range terms {
+			terms[i] = term + "*"
+		}
+	}
+	return strings.Join(terms, " ")
+}
+
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// toSubstringTerms converts the keyword into the terms which a sentence must all contain. It returns nil if the keyword has no terms.
+// Words and prefixes are matched as substrings because they are used only for ngramLang3s, whose words are not delimited
+func toSubstringTerms(keyword string, matchMode service.MatchMode) []string {
+	var terms []string
+	switch matchMode {
+	case service.MatchModeSubstring:
+		terms = []string{keyword}
+	case service.MatchModePhrase:
+		terms = []string{strings.Join(strings.Fields(keyword), " ")}
+	default:
+		terms = strings.Fields(keyword)
+	}
+
+	results := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term != "" {
+			results = append(results, term)
+		}
+	}
+	return results
+}
+
+// toLikePatterns converts the terms into the patterns of LIKE
+func toLikePatterns(terms []string) []string {
+	patterns := make([]string, len(terms))
+	for i, term := range terms {
+		patterns[i] = "%" + likeEscapeReplacer.Replace(term) + "%"
+	}
+	return patterns
+}
+
+// toTrigramQuery converts the terms into the query of the MATCH operator of the trigram table, which matches the sentences containing all the terms.
+// It returns an empty string if there are no terms
+func toTrigramQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = "\"" + strings.ReplaceAll(term, "\"", "\"\"") + "\""
+	}
+	return strings.Join(quoted, " ")
+}
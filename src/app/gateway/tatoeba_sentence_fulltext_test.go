@@ -0,0 +1,117 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func Test_tatoebaSentenceRepository_FindTatoebaSentencePairs_keyword(t *testing.T) {
+	sentences := []testSentence{
+		{sentenceNumber: 1, lang3: "eng", text: "I like green tea."},
+		{sentenceNumber: 2, lang3: "jpn", text: "私は緑茶が好きです。"},
+		{sentenceNumber: 3, lang3: "eng", text: "Tea time!"},
+		{sentenceNumber: 4, lang3: "jpn", text: "お茶の時間！"},
+		{sentenceNumber: 5, lang3: "eng", text: "50% off_sale"},
+		{sentenceNumber: 6, lang3: "jpn", text: "半額セール"},
+	}
+	links := [][2]int{{1, 2}, {3, 4}, {5, 6}}
+
+	tests := []struct {
+		name       string
+		srcLang3   string
+		dstLang3   string
+		keyword    string
+		dstKeyword string
+		matchMode  service.MatchMode
+		target     service.KeywordTarget
+		want       []int
+	}{
+		{name: "word", srcLang3: "eng", dstLang3: "jpn", keyword: "tea", matchMode: service.MatchModeWord, want: []int{1, 3}},
+		{name: "word is not a substring", srcLang3: "eng", dstLang3: "jpn", keyword: "gree", matchMode: service.MatchModeWord, want: []int{}},
+		{name: "prefix", srcLang3: "eng", dstLang3: "jpn", keyword: "gre", matchMode: service.MatchModePrefix, want: []int{1}},
+		{name: "phrase", srcLang3: "eng", dstLang3: "jpn", keyword: "green tea", matchMode: service.MatchModePhrase, want: []int{1}},
+		{name: "phrase in another order", srcLang3: "eng", dstLang3: "jpn", keyword: "tea green", matchMode: service.MatchModePhrase, want: []int{}},
+		{name: "substring with a wildcard of LIKE", srcLang3: "eng", dstLang3: "jpn", keyword: "0% o", matchMode: service.MatchModeSubstring, want: []int{5}},
+		{name: "substring with an escaped underscore", srcLang3: "eng", dstLang3: "jpn", keyword: "a_t", matchMode: service.MatchModeSubstring, want: []int{}},
+		{name: "word of Japanese", srcLang3: "jpn", dstLang3: "eng", keyword: "緑茶", matchMode: service.MatchModeWord, want: []int{2}},
+		{name: "words of Japanese", srcLang3: "jpn", dstLang3: "eng", keyword: "緑茶 好き", matchMode: service.MatchModeWord, want: []int{2}},
+		{name: "long word of Japanese", srcLang3: "jpn", dstLang3: "eng", keyword: "緑茶が好き", matchMode: service.MatchModeWord, want: []int{2}},
+		{name: "long and short words of Japanese", srcLang3: "jpn", dstLang3: "eng", keyword: "緑茶が 好き", matchMode: service.MatchModeWord, want: []int{2}},
+		{name: "long word of Japanese not in the text", srcLang3: "jpn", dstLang3: "eng", keyword: "紅茶が好き", matchMode: service.MatchModeWord, want: []int{}},
+		{name: "substring of Japanese", srcLang3: "jpn", dstLang3: "eng", keyword: "茶の時", matchMode: service.MatchModeSubstring, want: []int{4}},
+		{name: "prefix of Japanese", srcLang3: "jpn", dstLang3: "eng", keyword: "お茶", matchMode: service.MatchModePrefix, want: []int{4}},
+		{name: "phrase of Japanese", srcLang3: "jpn", dstLang3: "eng", keyword: "時間", matchMode: service.MatchModePhrase, want: []int{4}},
+		{name: "punctuation only", srcLang3: "eng", dstLang3: "jpn", keyword: "!!!", matchMode: service.MatchModeWord, want: []int{}},
+		{name: "operators only", srcLang3: "eng", dstLang3: "jpn", keyword: "+- *", matchMode: service.MatchModePrefix, want: []int{}},
+		{name: "dst", srcLang3: "eng", dstLang3: "jpn", keyword: "セール", matchMode: service.MatchModeWord, target: service.KeywordTargetDst, want: []int{5}},
+		{name: "either", srcLang3: "eng", dstLang3: "jpn", keyword: "時間", matchMode: service.MatchModeWord, target: service.KeywordTargetEither, want: []int{3}},
+		{name: "both", srcLang3: "eng", dstLang3: "jpn", keyword: "tea", dstKeyword: "緑茶", matchMode: service.MatchModeWord, target: service.KeywordTargetBoth, want: []int{1}},
+	}
+
+	for driverName, db := range dbList() {
+		truncateTables(t, db)
+		addSentences(t, db, driverName, sentences, links)
+		repo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+		require.NoError(t, err)
+
+		for _, tt := range tests {
+			t.Run(driverName+"/"+tt.name, func(t *testing.T) {
+				target := tt.target
+				if target == "" {
+					target = service.KeywordTargetSrc
+				}
+				keyword, err := service.NewTatoebaSentenceKeywordCondition(tt.keyword, tt.dstKeyword, tt.matchMode, target)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, findSrcSentenceNumbers(t, repo, tt.srcLang3, tt.dstLang3, keyword, nil))
+			})
+		}
+	}
+}
+
+func Test_tatoebaSentenceRepository_trigramIndex(t *testing.T) {
+	ctx := context.Background()
+	db, ok := dbList()["sqlite3"]
+	require.True(t, ok)
+
+	findIndexed := func(t *testing.T, term string) []int {
+		sentenceNumbers := make([]int, 0)
+		require.NoError(t, db.Raw("SELECT rowid FROM tatoeba_sentence_trigram_fts WHERE tatoeba_sentence_trigram_fts MATCH ? ORDER BY rowid", "\""+term+"\"").
+			Scan(&sentenceNumbers).Error)
+		return sentenceNumbers
+	}
+
+	truncateTables(t, db)
+	addSentences(t, db, "sqlite3", []testSentence{
+		{sentenceNumber: 1, lang3: "jpn", text: "緑茶が好きです。"},
+		{sentenceNumber: 2, lang3: "eng", text: "緑茶が好きです。"},
+	}, nil)
+	repo, err := gateway.NewTatoebaSentenceRepository(db, "sqlite3")
+	require.NoError(t, err)
+
+	// only the sentences of ngramLang3s are indexed
+	assert.Equal(t, []int{1}, findIndexed(t, "緑茶が"))
+
+	_, err = repo.SyncBatch(ctx, []service.TatoebaSentenceAddParameter{
+		newSentenceAddParameter(t, testSentence{sentenceNumber: 1, lang3: "jpn", text: "紅茶が好きです。"}, time.Now()),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, findIndexed(t, "緑茶が"))
+	assert.Equal(t, []int{1}, findIndexed(t, "紅茶が"))
+
+	// the index is not changed by the updates of the other columns
+	difficulty, err := service.NewTatoebaSentenceDifficultyParameter(1, 50, 3)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateDifficultiesAndWordCounts(ctx, []service.TatoebaSentenceDifficultyParameter{difficulty}))
+	assert.Equal(t, []int{1}, findIndexed(t, "紅茶が"))
+
+	_, err = repo.RemoveTatoebaSentences(ctx, []int{1})
+	require.NoError(t, err)
+	assert.Empty(t, findIndexed(t, "紅茶が"))
+}
@@ -1,28 +1,38 @@
 package gateway
 
 import (
-	"bufio"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
-	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/lib/log"
 )
 
 const (
-	bufSize         = 4096
-	textLimitLength = 100
+	bufSize = 4096
+	// maxLineLength is far longer than any sentence of Tatoeba
+	maxLineLength = 64 * 1024
+	// maxReasonValueLength is the length of the value quoted in the reason of a rejected row
+	maxReasonValueLength = 100
+	// MaxTextLimitLength is the length of the text column. A longer text would be truncated by MySQL
+	MaxTextLimitLength = 500
+
+	detailedColumnCount = 6
+	shortColumnCount    = 4
 )
 
+// errTextTooLong is the reason of a well-formed sentence which is rejected only for its length
+var errTextTooLong = errors.New("text is too long")
+
 type tatoebaSentenceAddParameterReader struct {
 	// reader *csv.Reader
-	reader *bufio.Reader
-	num    int
+	reader          *tsvRowReader
+	textLimitLength int
 }
 
 // type wrdomainedReader struct {
@@ -39,8 +49,13 @@ type tatoebaSentenceAddParameterReader struct {
 // 	return n + len(s2) - len(s1), nil
 // }
 
-func NewTatoebaSentenceAddParameterReader(reader io.Reader) service.TatoebaSentenceAddParameterIterator {
-	bufReader := bufio.NewReaderSize(reader, bufSize)
+// NewTatoebaSentenceAddParameterReader returns the iterator which rejects sentences longer than textLimitLength bytes.
+// textLimitLength out of the range from 1 to MaxTextLimitLength is replaced with MaxTextLimitLength.
+// The input can be compressed with gzip or bzip2 and archived with tar
+func NewTatoebaSentenceAddParameterReader(reader io.Reader, textLimitLength int) service.TatoebaSentenceAddParameterIterator {
+	if textLimitLength <= 0 || textLimitLength > MaxTextLimitLength {
+		textLimitLength = MaxTextLimitLength
+	}
 	// wrappedReader:=
 
 	// csvReader := csv.NewReader(reader)
@@ -49,84 +64,78 @@ func NewTatoebaSentenceAddParameterReader(reader io.Reader) service.TatoebaSente
 
 	return &tatoebaSentenceAddParameterReader{
 		// reader: csvReader,
-		reader: bufReader,
-		num:    1,
+		reader:          newTSVRowReader(reader, detailedColumnCount, shortColumnCount),
+		textLimitLength: textLimitLength,
 	}
 }
 
+// Next parses a row of sentences_detailed.csv, which has 6 columns, or its 4 column variant without the dates
 func (r *tatoebaSentenceAddParameterReader) Next(ctx context.Context) (service.TatoebaSentenceAddParameter, error) {
-	logger := log.FromContext(ctx)
-
-	b, _, err := r.reader.ReadLine()
-	if err != nil {
+	var param service.TatoebaSentenceAddParameter
+	if err := r.reader.next(func(columns []string) error {
+		tmpParam, err := r.parse(columns)
+		param = tmpParam
+		return err
+	}); err != nil {
+		log.FromContext(ctx).Debugf("reject row. err: %v", err)
 		return nil, err
 	}
-	if len(b) == 0 {
-		logger.Infof("zero")
-	}
-	line := strings.Split(string(b), "\t")
-	// var line []string
-	// line, err := r.reader.Read()
-	// if errors.Is(err, io.EOF) {
-	// 	return nil, err
-	// }
 
-	if err != nil {
-		// skip
-		logger.Infof("skip rowNumber: %d, line: %v", r.num, line)
-		r.num++
-		return nil, nil
-	}
+	return param, nil
+}
 
-	sentenceNumber, err := strconv.Atoi(line[0])
-	if err != nil {
-		return nil, liberrors.Errorf("failed to parse sentenceNumber. rowNumber: %d, value: %s, err: %w", r.num, line[0], err)
+func (r *tatoebaSentenceAddParameterReader) parse(columns []string) (service.TatoebaSentenceAddParameter, error) {
+	sentenceNumber, err := strconv.Atoi(columns[0])
+	if err != nil || sentenceNumber <= 0 {
+		return nil, fmt.Errorf("invalid sentence number. value: %s", truncateRunes(columns[0], maxReasonValueLength))
 	}
 
-	lang3, err := domain.NewLang3(line[1])
+	lang3, err := domain.NewLang3(columns[1])
 	if err != nil {
-		return nil, liberrors.Errorf("failed to NewLang3. rowNumber: %d, value: %s, err: %w", r.num, line[1], err)
+		return nil, fmt.Errorf("invalid language. value: %s", truncateRunes(columns[1], maxReasonValueLength))
 	}
 
-	text := line[2]
-	author := line[3]
-
-	time1 := line[4]
-	time2 := line[5]
-	updatedAt := time.Now()
+	text := columns[2]
+	if text == "" {
+		return nil, errors.New("empty text")
+	}
+	if len(text) > r.textLimitLength {
+		return nil, fmt.Errorf("%w. limit: %d bytes, length: %d bytes", errTextTooLong, r.textLimitLength, len(text))
+	}
 
-	if len(text) > textLimitLength {
-		// skip
-		logger.Debugf("skip long text. rowNumber: %d, text: %s", r.num, text)
-		r.num++
-		return nil, nil
+	author := columns[3]
+	if author == "" {
+		return nil, errors.New("empty author")
 	}
 
-	//\N	2020-02-23 05:07:26
-	if r.isValidDatetime(time1) || r.isValidDatetime(time2) {
-		var timeS string
-		if r.isValidDatetime(time1) {
-			timeS = time1
-		} else if r.isValidDatetime(time2) {
-			timeS = time2
+	updatedAt := time.Now()
+	if len(columns) == detailedColumnCount {
+		// date added and date last modified. \N	2020-02-23 05:07:26
+		// the date last modified takes precedence, because the sync mode updates only the sentences newer than the stored ones
+		timeS := ""
+		if r.isValidDatetime(columns[5]) {
+			timeS = columns[5]
+		} else if r.isValidDatetime(columns[4]) {
+			timeS = columns[4]
 		}
 
-		timeTmp, err := time.Parse("2006-01-02 15:04:05", timeS)
-		if err != nil {
-			return nil, liberrors.Errorf("failed to Parse. rowNumber: %d, value: %s, err: %w", r.num, timeS, err)
+		if timeS != "" {
+			timeTmp, err := time.Parse("2006-01-02 15:04:05", timeS)
+			if err != nil {
+				return nil, fmt.Errorf("invalid datetime. value: %s", truncateRunes(timeS, maxReasonValueLength))
+			}
+			updatedAt = timeTmp
 		}
-		updatedAt = timeTmp
 	}
 
 	param, err := service.NewTatoebaSentenceAddParameter(sentenceNumber, lang3, text, author, updatedAt)
 	if err != nil {
-		return nil, liberrors.Errorf("failed to NewTatoebaSentenceAddParameter. rowNumber: %d, values: %v, err: %w", r.num, line, err)
+		return nil, fmt.Errorf("invalid sentence. %v", err)
 	}
 
-	r.num++
 	return param, nil
 }
 
 func (r *tatoebaSentenceAddParameterReader) isValidDatetime(value string) bool {
-	return value != "\\N" && value != "0000-00-00 00:00:00"
+	return value != "" && value != "\\N" && value != "0000-00-00 00:00:00"
 }
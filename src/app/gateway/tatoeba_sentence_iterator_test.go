@@ -0,0 +1,63 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func Test_tatoebaSentenceAddParameterReader_updatedAt(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want time.Time
+	}{
+		{name: "last modified", line: "1\teng\tHello.\talice\t2010-01-01 00:00:00\t2020-02-23 05:07:26", want: time.Date(2020, 2, 23, 5, 7, 26, 0, time.UTC)},
+		{name: "added only", line: "1\teng\tHello.\talice\t2010-01-01 00:00:00\t\\N", want: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "zero last modified", line: "1\teng\tHello.\talice\t2010-01-01 00:00:00\t0000-00-00 00:00:00", want: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator := gateway.NewTatoebaSentenceAddParameterReader(strings.NewReader(tt.line+"\n"), 100)
+			param, err := iterator.Next(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, param.GetUpdatedAt())
+		})
+	}
+}
+
+func Test_tatoebaSentenceAddParameterReader_rejected(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want service.RejectCode
+	}{
+		{name: "too many columns", line: "1\teng\tHello.\talice\t\\N\t\\N\textra", want: service.RejectCodeMalformed},
+		{name: "invalid sentence number", line: "x\teng\tHello.\talice", want: service.RejectCodeMalformed},
+		{name: "empty text", line: "1\teng\t\talice", want: service.RejectCodeMalformed},
+		{name: "text longer than the limit", line: "1\teng\tHello, world.\talice", want: service.RejectCodeTextTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iterator := gateway.NewTatoebaSentenceAddParameterReader(strings.NewReader(tt.line+"\nnext\n"), 10)
+			_, err := iterator.Next(context.Background())
+			rejected := &service.RejectedRowError{}
+			require.True(t, errors.As(err, &rejected))
+			assert.Equal(t, 1, rejected.RowNumber)
+			assert.Equal(t, tt.want, rejected.Code)
+
+			// the iterator continues with the next row
+			_, err = iterator.Next(context.Background())
+			require.True(t, errors.As(err, &rejected))
+			assert.Equal(t, 2, rejected.RowNumber)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+const cc0SentenceColumnCount = 4
+
+type tatoebaCC0SentenceLicenseParameterReader struct {
+	reader *tsvRowReader
+}
+
+// NewTatoebaCC0SentenceLicenseParameterReader returns the iterator of the sentences released under CC0. The input can be compressed with gzip or bzip2 and archived with tar
+func NewTatoebaCC0SentenceLicenseParameterReader(reader io.Reader) service.TatoebaSentenceLicenseParameterIterator {
+	return &tatoebaCC0SentenceLicenseParameterReader{
+		reader: newTSVRowReader(reader, cc0SentenceColumnCount),
+	}
+}
+
+// Next parses a row of sentences_CC0.csv, which has the sentence number, the language, the text and the date last modified.
+// Only the sentence number is used because the other columns are imported from sentences_detailed.csv
+func (r *tatoebaCC0SentenceLicenseParameterReader) Next(ctx context.Context) (service.TatoebaSentenceLicenseParameter, error) {
+	var param service.TatoebaSentenceLicenseParameter
+	if err := r.reader.next(func(columns []string) error {
+		tmpParam, err := r.parse(columns)
+		param = tmpParam
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return param, nil
+}
+
+func (r *tatoebaCC0SentenceLicenseParameterReader) parse(columns []string) (service.TatoebaSentenceLicenseParameter, error) {
+	sentenceNumber, err := strconv.Atoi(columns[0])
+	if err != nil || sentenceNumber <= 0 {
+		return nil, fmt.Errorf("invalid sentence number. value: %s", truncateRunes(columns[0], maxReasonValueLength))
+	}
+
+	param, err := service.NewTatoebaSentenceLicenseParameter(sentenceNumber, service.TatoebaSentenceLicenseCC0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentence number. value: %d", sentenceNumber)
+	}
+
+	return param, nil
+}
@@ -3,9 +3,10 @@ package gateway
 import (
 	"context"
 	"errors"
-	"math/rand"
+	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"gorm.io/gorm"
 
@@ -18,7 +19,74 @@ import (
 )
 
 const (
-	shuffleBufferRate = 10
+	maxKeywordCount = 10000
+
+	// noMatchExpression is the condition which matches nothing
+	noMatchExpression = "1 = 0"
+
+	randomModulus    = 2147483647
+	randomMultiplier = 48271
+
+	tatoebaSentencePairColumns = "" +
+		// Src
+		"T1.sentence_number AS src_sentence_number," +
+		"T1.lang3 AS src_lang3," +
+		"T1.text AS src_text," +
+		"T1.author AS src_author," +
+		"T1.updated_at AS src_updated_at," +
+		"T1.difficulty AS src_difficulty," +
+		"T1.word_count AS src_word_count," +
+		"T1.license AS src_license," +
+		// Dst
+		"T3.sentence_number AS dst_sentence_number," +
+		"T3.lang3 AS dst_lang3," +
+		"T3.text AS dst_text," +
+		"T3.author AS dst_author," +
+		"T3.updated_at AS dst_updated_at," +
+		"T3.difficulty AS dst_difficulty," +
+		"T3.word_count AS dst_word_count," +
+		"T3.license AS dst_license"
+
+	tatoebaSentencePivotColumns = "" +
+		"T4.sentence_number AS pivot_sentence_number," +
+		"T4.lang3 AS pivot_lang3," +
+		"T4.text AS pivot_text," +
+		"T4.author AS pivot_author," +
+		"T4.updated_at AS pivot_updated_at," +
+		"T4.difficulty AS pivot_difficulty," +
+		"T4.word_count AS pivot_word_count," +
+		"T4.license AS pivot_license"
+
+	// twoHopLinkQuery returns links between the source and destination languages with the pivot sentence.
+	// Direct links have no pivot. Pairs linked through pivot sentences are returned once with the smallest pivot, unless they are linked directly.
+	twoHopLinkQuery = "" +
+		"SELECT L1.`from`, L1.`to`, NULL AS pivot_sentence_number" +
+		" FROM tatoeba_link AS L1" +
+		" INNER JOIN tatoeba_sentence AS S1 ON S1.sentence_number = L1.`from`" +
+		" INNER JOIN tatoeba_sentence AS S3 ON S3.sentence_number = L1.`to`" +
+		" WHERE S1.lang3 = ? AND S3.lang3 = ?" +
+		" UNION ALL" +
+		" SELECT L1.`from`, L2.`to`, MIN(L1.`to`) AS pivot_sentence_number" +
+		" FROM tatoeba_link AS L1" +
+		" INNER JOIN tatoeba_link AS L2 ON L2.`from` = L1.`to`" +
+		" INNER JOIN tatoeba_sentence AS S1 ON S1.sentence_number = L1.`from`" +
+		" INNER JOIN tatoeba_sentence AS S3 ON S3.sentence_number = L2.`to`" +
+		" WHERE S1.lang3 = ? AND S3.lang3 = ? AND L2.`to` <> L1.`from`" +
+		" AND NOT EXISTS (SELECT 1 FROM tatoeba_link AS L3 WHERE L3.`from` = L1.`from` AND L3.`to` = L2.`to`)" +
+		" GROUP BY L1.`from`, L2.`to`"
+
+	// translationLinkQuery returns links from the sentence with the pivot sentence in the same way as twoHopLinkQuery
+	translationLinkQuery = "" +
+		"SELECT L1.`from`, L1.`to`, NULL AS pivot_sentence_number" +
+		" FROM tatoeba_link AS L1" +
+		" WHERE L1.`from` = ?" +
+		" UNION ALL" +
+		" SELECT L1.`from`, L2.`to`, MIN(L1.`to`) AS pivot_sentence_number" +
+		" FROM tatoeba_link AS L1" +
+		" INNER JOIN tatoeba_link AS L2 ON L2.`from` = L1.`to`" +
+		" WHERE L1.`from` = ? AND L2.`to` <> L1.`from`" +
+		" AND NOT EXISTS (SELECT 1 FROM tatoeba_link AS L3 WHERE L3.`from` = L1.`from` AND L3.`to` = L2.`to`)" +
+		" GROUP BY L1.`from`, L2.`to`"
 )
 
 type tatoebaSentenceEntity struct {
@@ -27,6 +95,10 @@ type tatoebaSentenceEntity struct {
 	Text           string
 	Author         string
 	UpdatedAt      time.Time
+	Difficulty     int
+	TextLength     int
+	WordCount      int
+	License        string
 }
 
 type tatoebaSentencePairEntity struct {
@@ -35,14 +107,40 @@ type tatoebaSentencePairEntity struct {
 	SrcText           string
 	SrcAuthor         string
 	SrcUpdatedAt      time.Time
+	SrcDifficulty     int
+	SrcWordCount      int
+	SrcLicense        string
 	DstSentenceNumber int
 	DstLang3          string
 	DstText           string
 	DstAuthor         string
 	DstUpdatedAt      time.Time
+	DstDifficulty     int
+	DstWordCount      int
+	DstLicense        string
+
+	PivotSentenceNumber *int
+	PivotLang3          *string
+	PivotText           *string
+	PivotAuthor         *string
+	PivotUpdatedAt      *time.Time
+	PivotDifficulty     *int
+	PivotWordCount      *int
+	PivotLicense        *string
 }
 
-func (e *tatoebaSentenceEntity) toModel() (service.TatoebaSentence, error) {
+type tatoebaSentencePairCountEntity struct {
+	SrcLang3  string
+	DstLang3  string
+	PairCount int
+}
+
+func (e *tatoebaSentencePairCountEntity) TableName() string {
+	return "tatoeba_sentence_pair_count"
+}
+
+// toModel returns the sentence with its audios in the map. The map can be nil
+func (e *tatoebaSentenceEntity) toModel(audios map[int][]service.TatoebaAudio) (service.TatoebaSentence, error) {
 	lang3, err := domain.NewLang3(e.Lang3)
 	if err != nil {
 		return nil, liberrors.Errorf("failed to NewLang3. err: %w", err)
@@ -51,18 +149,21 @@ func (e *tatoebaSentenceEntity) toModel() (service.TatoebaSentence, error) {
 	if author == "\\N" {
 		author = ""
 	}
-	return service.NewTatoebaSentence(e.SentenceNumber, lang3, e.Text, author, e.UpdatedAt)
+	return service.NewTatoebaSentence(e.SentenceNumber, lang3, e.Text, author, e.UpdatedAt, e.Difficulty, e.WordCount, service.TatoebaSentenceLicense(e.License), audios[e.SentenceNumber])
 }
 
-func (e *tatoebaSentencePairEntity) toModel() (service.TatoebaSentencePair, error) {
+func (e *tatoebaSentencePairEntity) toModel(audios map[int][]service.TatoebaAudio) (service.TatoebaSentencePair, error) {
 	srcE := tatoebaSentenceEntity{
 		SentenceNumber: e.SrcSentenceNumber,
 		Lang3:          e.SrcLang3,
 		Text:           e.SrcText,
 		Author:         e.SrcAuthor,
 		UpdatedAt:      e.SrcUpdatedAt,
+		Difficulty:     e.SrcDifficulty,
+		WordCount:      e.SrcWordCount,
+		License:        e.SrcLicense,
 	}
-	srcM, err := srcE.toModel()
+	srcM, err := srcE.toModel(audios)
 	if err != nil {
 		return nil, err
 	}
@@ -73,13 +174,35 @@ func (e *tatoebaSentencePairEntity) toModel() (service.TatoebaSentencePair, erro
 		Text:           e.DstText,
 		Author:         e.DstAuthor,
 		UpdatedAt:      e.DstUpdatedAt,
+		Difficulty:     e.DstDifficulty,
+		WordCount:      e.DstWordCount,
+		License:        e.DstLicense,
 	}
-	dstM, err := dstE.toModel()
+	dstM, err := dstE.toModel(audios)
 	if err != nil {
 		return nil, err
 	}
 
-	return service.NewTatoebaSentencePair(srcM, dstM)
+	if e.PivotSentenceNumber == nil {
+		return service.NewTatoebaSentencePair(srcM, dstM, nil)
+	}
+
+	pivotE := tatoebaSentenceEntity{
+		SentenceNumber: *e.PivotSentenceNumber,
+		Lang3:          *e.PivotLang3,
+		Text:           *e.PivotText,
+		Author:         *e.PivotAuthor,
+		UpdatedAt:      *e.PivotUpdatedAt,
+		Difficulty:     *e.PivotDifficulty,
+		WordCount:      *e.PivotWordCount,
+		License:        *e.PivotLicense,
+	}
+	pivotM, err := pivotE.toModel(audios)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewTatoebaSentencePair(srcM, dstM, pivotM)
 }
 
 func (e *tatoebaSentenceEntity) TableName() string {
@@ -87,15 +210,17 @@ func (e *tatoebaSentenceEntity) TableName() string {
 }
 
 type tatoebaSentenceRepository struct {
-	db *gorm.DB
+	db         *gorm.DB
+	driverName string
 }
 
-func NewTatoebaSentenceRepository(db *gorm.DB) (service.TatoebaSentenceRepository, error) {
+func NewTatoebaSentenceRepository(db *gorm.DB, driverName string) (service.TatoebaSentenceRepository, error) {
 	if db == nil {
 		return nil, libD.ErrInvalidArgument
 	}
 	return &tatoebaSentenceRepository{
-		db: db,
+		db:         db,
+		driverName: driverName,
 	}, nil
 }
 
@@ -153,149 +278,395 @@ func (r *tatoebaSentenceRepository) FindTatoebaSentencePairs(ctx context.Context
 	defer span.End()
 
 	logger := log.FromContext(ctx)
-	logger.Debugf("keyword: %s, random: %v", param.GetKeyword(), param.IsRandom())
+	logger.Debugf("srcLang3: %s, dstLang3: %s, keyword: %+v, random: %v", param.GetSrcLang3().String(), param.GetDstLang3().String(), param.GetKeyword(), param.IsRandom())
 	if param.IsRandom() {
 		return r.findTatoebaSentencesByRandom(ctx, param)
 	}
 	return r.findTatoebaSentences(ctx, param)
 }
 
-func (r *tatoebaSentenceRepository) findTatoebaSentences(ctx context.Context, param service.TatoebaSentenceSearchCondition) (service.TatoebaSentencePairSearchResult, error) {
-	logger := log.FromContext(ctx)
-	logger.Debug("tatoebaSentenceRepository.FindTatoebaSentences")
-	limit := param.GetPageSize()
-	offset := (param.GetPageNo() - 1) * param.GetPageSize()
+func (r *tatoebaSentenceRepository) wherePair(param service.TatoebaSentenceSearchCondition) *gorm.DB {
+	db := r.db.Table("tatoeba_sentence AS T1")
+	if param.GetMaxHops() >= 2 {
+		links := r.db.Raw(twoHopLinkQuery,
+			param.GetSrcLang3().String(), param.GetDstLang3().String(),
+			param.GetSrcLang3().String(), param.GetDstLang3().String())
+		db = db.Joins("INNER JOIN (?) AS T2 ON T1.sentence_number = T2.`from`", links).
+			Joins("LEFT JOIN tatoeba_sentence AS T4 ON T4.sentence_number = T2.pivot_sentence_number")
+	} else {
+		db = db.Joins("INNER JOIN tatoeba_link AS T2 ON T1.sentence_number = T2.`from`")
+	}
+	db = db.Joins("INNER JOIN tatoeba_sentence AS T3 ON T3.sentence_number = T2.`to`").
+		Where("T1.lang3 = ? AND T3.lang3 = ?", param.GetSrcLang3().String(), param.GetDstLang3().String())
+	if param.GetKeyword() != nil {
+		db = r.whereKeyword(db, param)
+	}
+	if filter := param.GetSrcFilter(); filter != nil {
+		db = whereFilter(db, "T1", filter)
+	}
+	if filter := param.GetDstFilter(); filter != nil {
+		db = whereFilter(db, "T3", filter)
+	}
+	return db
+}
 
-	//db.Model(&User{}).Select("users.name, emails.email").Joins("left join emails on emails.user_id = users.id").Scan(&result{})
-
-	// 	FROM `sandbox` AS s
-	// INNER JOIN (
-	//   SELECT CEIL(RAND() * (SELECT MAX(`id`) FROM `sandbox`)) AS `id`
-	// ) AS `tmp` ON s.id >= tmp.id
-	// ORDER BY s.id
-
-	where := func() *gorm.DB {
-		db := r.db.Table("tatoeba_sentence AS T1").Select(
-			// Src
-			"T1.sentence_number AS src_sentence_number," +
-				"T1.lang3 AS src_lang3," +
-				"T1.text AS src_text," +
-				"T1.author AS src_author," +
-				"T1.updated_at AS src_updated_at," +
-				// Dst
-				"T3.sentence_number AS dst_sentence_number," +
-				"T3.lang3 AS dst_lang3," +
-				"T3.text AS dst_text," +
-				"T3.author AS dst_author," +
-				"T3.updated_at AS dst_updated_at").
-			Joins("INNER JOIN tatoeba_link AS T2 ON T1.sentence_number = T2.`from`").
-			Joins("INNER JOIN tatoeba_sentence AS T3 ON T3.sentence_number = T2.`to`").
-			Where("T1.lang3 = 'eng' AND T3.lang3 = 'jpn'")
-		if param.GetKeyword() != "" {
-			keyword1 := strings.ReplaceAll(param.GetKeyword(), "%", "\\%")
-			keyword2 := "%" + keyword1 + "%"
-			db = db.Where("T1.text like ?", keyword2)
+// selectPair returns the query of pairs with the columns of the source, destination and pivot sentences
+func (r *tatoebaSentenceRepository) selectPair(param service.TatoebaSentenceSearchCondition) *gorm.DB {
+	if param.GetMaxHops() >= 2 {
+		return r.wherePair(param).Select(tatoebaSentencePairColumns + "," + tatoebaSentencePivotColumns)
+	}
+	return r.wherePair(param).Select(tatoebaSentencePairColumns)
+}
+
+// toPairModels returns the pairs with the audios of their sentences
+func (r *tatoebaSentenceRepository) toPairModels(entities []tatoebaSentencePairEntity) ([]service.TatoebaSentencePair, error) {
+	sentenceNumbers := make([]int, 0, len(entities)*3)
+	for _, e := range entities {
+		sentenceNumbers = append(sentenceNumbers, e.SrcSentenceNumber, e.DstSentenceNumber)
+		if e.PivotSentenceNumber != nil {
+			sentenceNumbers = append(sentenceNumbers, *e.PivotSentenceNumber)
 		}
-		return db
 	}
 
-	entities := []tatoebaSentencePairEntity{}
-	if result := where().Limit(limit).Offset(offset).Scan(&entities); result.Error != nil {
-		return nil, result.Error
+	audios, err := findTatoebaAudiosBySentenceNumbers(r.db, sentenceNumbers)
+	if err != nil {
+		return nil, err
 	}
 
 	results := make([]service.TatoebaSentencePair, len(entities))
 	for i, e := range entities {
-		m, err := e.toModel()
+		m, err := e.toModel(audios)
 		if err != nil {
 			return nil, err
 		}
 		results[i] = m
 	}
 
-	var count int64 = 0
-	// if result := where().Count(&count); result.Error != nil {
-	// 	return nil, result.Error
-	// }
+	return results, nil
+}
 
-	return service.NewTatoebaSentencePairSearchResult(int(count), results), nil
+// whereFilter restricts the sentences of the table alias to those satisfying the filter
+func whereFilter(db *gorm.DB, alias string, filter service.TatoebaSentenceFilter) *gorm.DB {
+	if filter.GetMinDifficulty() > domain.MinDifficulty {
+		db = db.Where(alias+".difficulty >= ?", filter.GetMinDifficulty())
+	}
+	if filter.GetMaxDifficulty() < domain.MaxDifficulty {
+		db = db.Where(alias+".difficulty <= ?", filter.GetMaxDifficulty())
+	}
+	if filter.GetMinLength() > 0 {
+		db = db.Where(alias+".text_length >= ?", filter.GetMinLength())
+	}
+	if filter.GetMaxLength() > 0 {
+		db = db.Where(alias+".text_length <= ?", filter.GetMaxLength())
+	}
+	if filter.GetMinWordCount() > 0 {
+		db = db.Where(alias+".word_count >= ?", filter.GetMinWordCount())
+	}
+	if filter.GetMaxWordCount() > 0 {
+		db = db.Where(alias+".word_count <= ?", filter.GetMaxWordCount())
+	}
+	if len(filter.GetIncludeAuthors()) > 0 {
+		db = db.Where(alias+".author IN ?", filter.GetIncludeAuthors())
+	}
+	if len(filter.GetExcludeAuthors()) > 0 {
+		db = db.Where(alias+".author NOT IN ?", filter.GetExcludeAuthors())
+	}
+	if len(filter.GetIncludeTags()) > 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM tatoeba_tag WHERE tatoeba_tag.sentence_number = "+alias+".sentence_number AND tatoeba_tag.tag_name IN ?)", filter.GetIncludeTags())
+	}
+	if len(filter.GetExcludeTags()) > 0 {
+		db = db.Where("NOT EXISTS (SELECT 1 FROM tatoeba_tag WHERE tatoeba_tag.sentence_number = "+alias+".sentence_number AND tatoeba_tag.tag_name IN ?)", filter.GetExcludeTags())
+	}
+	if filter.GetLicense() != "" {
+		db = db.Where(alias+".license = ?", string(filter.GetLicense()))
+	}
+	if filter.IsWithAudioOnly() {
+		db = db.Where("EXISTS (SELECT 1 FROM tatoeba_audio WHERE tatoeba_audio.sentence_number = " + alias + ".sentence_number)")
+	}
+	if filter.GetUpdatedAfter() != nil {
+		db = db.Where(alias+".updated_at > ?", *filter.GetUpdatedAfter())
+	}
+	if filter.GetUpdatedBefore() != nil {
+		db = db.Where(alias+".updated_at < ?", *filter.GetUpdatedBefore())
+	}
+	return db
 }
 
-func min(x, y int) int {
-	if x < y {
-		return x
+func (r *tatoebaSentenceRepository) whereKeyword(db *gorm.DB, param service.TatoebaSentenceSearchCondition) *gorm.DB {
+	keyword := param.GetKeyword()
+	srcLang3, dstLang3 := param.GetSrcLang3().String(), param.GetDstLang3().String()
+	matchMode := keyword.GetMatchMode()
+	switch keyword.GetTarget() {
+	case service.KeywordTargetDst:
+		query, args := r.keywordExpression("T3", dstLang3, keyword.GetKeyword(), matchMode)
+		return db.Where(query, args...)
+	case service.KeywordTargetEither:
+		srcQuery, srcArgs := r.keywordExpression("T1", srcLang3, keyword.GetKeyword(), matchMode)
+		dstQuery, dstArgs := r.keywordExpression("T3", dstLang3, keyword.GetKeyword(), matchMode)
+		return db.Where("("+srcQuery+") OR ("+dstQuery+")", append(srcArgs, dstArgs...)...)
+	case service.KeywordTargetBoth:
+		srcQuery, srcArgs := r.keywordExpression("T1", srcLang3, keyword.GetKeyword(), matchMode)
+		dstQuery, dstArgs := r.keywordExpression("T3", dstLang3, keyword.GetDstKeyword(), matchMode)
+		return db.Where(srcQuery, srcArgs...).Where(dstQuery, dstArgs...)
+	default:
+		query, args := r.keywordExpression("T1", srcLang3, keyword.GetKeyword(), matchMode)
+		return db.Where(query, args...)
 	}
-	return y
 }
 
-func (r *tatoebaSentenceRepository) findTatoebaSentencesByRandom(ctx context.Context, param service.TatoebaSentenceSearchCondition) (service.TatoebaSentencePairSearchResult, error) {
+// keywordExpression returns the condition which matches the text of the table alias in the language against the keyword.
+// A keyword without terms, like one made only of punctuation, matches nothing
+func (r *tatoebaSentenceRepository) keywordExpression(alias, lang3, keyword string, matchMode service.MatchMode) (string, []interface{}) {
+	if ngramLang3s[lang3] && r.driverName == "sqlite3" {
+		return r.trigramExpression(alias, toSubstringTerms(keyword, matchMode))
+	}
+	if matchMode == service.MatchModeSubstring {
+		return r.likeExpression(alias, toLikePatterns(toSubstringTerms(keyword, matchMode)))
+	}
+
+	if r.driverName == "sqlite3" {
+		query := toFTS5Query(keyword, matchMode)
+		if query == "" {
+			return noMatchExpression, nil
+		}
+		return alias + ".sentence_number IN (SELECT rowid FROM tatoeba_sentence_fts WHERE tatoeba_sentence_fts MATCH ?)", []interface{}{query}
+	}
+
+	query := toMySQLBooleanQuery(keyword, matchMode)
+	if query == "" {
+		return noMatchExpression, nil
+	}
+	if ngramLang3s[lang3] {
+		return "MATCH(" + alias + ".ngram_text) AGAINST(? IN BOOLEAN MODE)", []interface{}{query}
+	}
+	return "MATCH(" + alias + ".text) AGAINST(? IN BOOLEAN MODE)", []interface{}{query}
+}
+
+// trigramExpression returns the condition which matches the text of the table alias against all the terms with the trigram table of SQLite.
+// Trigrams cannot match terms shorter than 3 characters, so they are matched by LIKE
+func (r *tatoebaSentenceRepository) trigramExpression(alias string, terms []string) (string, []interface{}) {
+	if len(terms) == 0 {
+		return noMatchExpression, nil
+	}
+
+	indexedTerms := make([]string, 0, len(terms))
+	shortTerms := make([]string, 0)
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) >= trigramLength {
+			indexedTerms = append(indexedTerms, term)
+		} else {
+			shortTerms = append(shortTerms, term)
+		}
+	}
+
+	conditions := make([]string, 0, 2)
+	args := make([]interface{}, 0, len(shortTerms)+1)
+	if len(indexedTerms) > 0 {
+		conditions = append(conditions, alias+".sentence_number IN (SELECT rowid FROM tatoeba_sentence_trigram_fts WHERE tatoeba_sentence_trigram_fts MATCH ?)")
+		args = append(args, toTrigramQuery(indexedTerms))
+	}
+	if len(shortTerms) > 0 {
+		likeQuery, likeArgs := r.likeExpression(alias, toLikePatterns(shortTerms))
+		conditions = append(conditions, likeQuery)
+		args = append(args, likeArgs...)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// likeExpression returns the condition which matches the text of the table alias against all the patterns
+func (r *tatoebaSentenceRepository) likeExpression(alias string, patterns []string) (string, []interface{}) {
+	if len(patterns) == 0 {
+		return noMatchExpression, nil
+	}
+
+	// MySQL escapes with the backslash by default, while SQLite has no escape character by default
+	like := alias + ".text LIKE ?"
+	if r.driverName == "sqlite3" {
+		like += " ESCAPE '\\'"
+	}
+
+	conditions := make([]string, len(patterns))
+	args := make([]interface{}, len(patterns))
+	for i, pattern := range patterns {
+		conditions[i] = like
+		args[i] = pattern
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// countTatoebaSentencePairs returns the number of pairs matching the condition.
+// Without a keyword, filters and indirect pairs the precomputed count of the language pair is used.
+// Otherwise the count stops at maxKeywordCount and the returned flag reports whether it was capped.
+func (r *tatoebaSentenceRepository) countTatoebaSentencePairs(ctx context.Context, param service.TatoebaSentenceSearchCondition) (int, bool, error) {
+	if param.GetKeyword() == nil && param.GetSrcFilter() == nil && param.GetDstFilter() == nil && param.GetMaxHops() < 2 {
+		entity := tatoebaSentencePairCountEntity{}
+		if result := r.db.Where("src_lang3 = ? AND dst_lang3 = ?", param.GetSrcLang3().String(), param.GetDstLang3().String()).
+			Limit(1).Find(&entity); result.Error != nil {
+			return 0, false, result.Error
+		}
+		return entity.PairCount, false, nil
+	}
+
+	var count int64
+	subQuery := r.wherePair(param).Select("1").Limit(maxKeywordCount + 1)
+	if result := r.db.Table("(?) AS T", subQuery).Count(&count); result.Error != nil {
+		return 0, false, result.Error
+	}
+	if count > maxKeywordCount {
+		return maxKeywordCount, true, nil
+	}
+	return int(count), false, nil
+}
+
+func (r *tatoebaSentenceRepository) findTatoebaSentences(ctx context.Context, param service.TatoebaSentenceSearchCondition) (service.TatoebaSentencePairSearchResult, error) {
 	logger := log.FromContext(ctx)
 	logger.Debug("tatoebaSentenceRepository.FindTatoebaSentences")
-	limit := param.GetPageSize() * shuffleBufferRate
-	offset := (param.GetPageNo() - 1) * param.GetPageSize()
+	limit := param.GetPageSize()
 
-	where := func() *gorm.DB {
-		db := r.db.Table("tatoeba_sentence AS T1").Select(
-			// Src
-			"T1.sentence_number AS src_sentence_number," +
-				"T1.lang3 AS src_lang3," +
-				"T1.text AS src_text," +
-				"T1.author AS src_author," +
-				"T1.updated_at AS src_updated_at," +
-				// Dst
-				"T3.sentence_number AS dst_sentence_number," +
-				"T3.lang3 AS dst_lang3," +
-				"T3.text AS dst_text," +
-				"T3.author AS dst_author," +
-				"T3.updated_at AS dst_updated_at").
-			Joins("INNER JOIN tatoeba_link AS T2 ON T1.sentence_number = T2.`from`").
-			Joins("INNER JOIN tatoeba_sentence AS T3 ON T3.sentence_number = T2.`to`").
-			Joins("INNER JOIN (SELECT CEIL(RAND() * (SELECT MAX(`sentence_number`) FROM `tatoeba_sentence`)) AS `sentence_number`) AS `tmp` ON T1.sentence_number >= tmp.sentence_number").
-			Where("T1.lang3 = 'eng' AND T3.lang3 = 'jpn'")
-		if param.GetKeyword() != "" {
-			keyword1 := strings.ReplaceAll(param.GetKeyword(), "%", "\\%")
-			keyword2 := "%" + keyword1 + "%"
-			db = db.Where("T1.text like ?", keyword2)
-		}
-		return db
+	if param.GetSortBy() != service.SortBySentenceNumber {
+		return r.findTatoebaSentencesBySortKey(ctx, param)
+	}
+
+	direction, comparison := "ASC", ">"
+	if param.GetSortOrder() == service.SortOrderDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	db := r.selectPair(param).
+		Order("T1.sentence_number " + direction + ", T3.sentence_number " + direction)
+	if cursor := param.GetCursor(); cursor != nil {
+		// keyset pagination
+		db = db.Where("T1.sentence_number "+comparison+" ? OR (T1.sentence_number = ? AND T3.sentence_number "+comparison+" ?)",
+			cursor.GetSrcSentenceNumber(), cursor.GetSrcSentenceNumber(), cursor.GetDstSentenceNumber())
+	} else {
+		db = db.Offset((param.GetPageNo() - 1) * param.GetPageSize())
 	}
 
 	entities := []tatoebaSentencePairEntity{}
-	if result := where().Limit(limit).Offset(offset).Scan(&entities); result.Error != nil {
+	if result := db.Limit(limit).Scan(&entities); result.Error != nil {
 		return nil, result.Error
 	}
 
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(entities), func(i, j int) { entities[i], entities[j] = entities[j], entities[i] })
-
-	logger.Infof("len(entities): %d", len(entities))
+	results, err := r.toPairModels(entities)
+	if err != nil {
+		return nil, err
+	}
 
-	length := min(param.GetPageSize(), len(entities))
-	results := make([]service.TatoebaSentencePair, length)
-	for i := 0; i < length; i++ {
-		m, err := entities[i].toModel()
+	var nextCursor service.TatoebaSentencePairCursor
+	if len(entities) == limit {
+		last := entities[len(entities)-1]
+		tmpCursor, err := service.NewTatoebaSentencePairCursor(last.SrcSentenceNumber, last.DstSentenceNumber)
 		if err != nil {
 			return nil, err
 		}
-		results[i] = m
+		nextCursor = tmpCursor
 	}
 
-	var count int64 = 0
-	// if result := where().Count(&count); result.Error != nil {
-	// 	return nil, result.Error
-	// }
+	count, capped, err := r.countTatoebaSentencePairs(ctx, param)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to countTatoebaSentencePairs. err: %w", err)
+	}
 
-	return service.NewTatoebaSentencePairSearchResult(int(count), results), nil
+	return service.NewTatoebaSentencePairSearchResult(count, capped, results, nextCursor), nil
+}
+
+// findTatoebaSentencesBySortKey returns pairs sorted by a column other than the sentence number.
+// Sentence numbers break ties so that the order is deterministic, and pages are fetched by offset because the sort key is not unique.
+func (r *tatoebaSentenceRepository) findTatoebaSentencesBySortKey(ctx context.Context, param service.TatoebaSentenceSearchCondition) (service.TatoebaSentencePairSearchResult, error) {
+	logger := log.FromContext(ctx)
+	logger.Debug("tatoebaSentenceRepository.FindTatoebaSentencesBySortKey")
+	limit := param.GetPageSize()
+	offset := (param.GetPageNo() - 1) * param.GetPageSize()
+
+	column := "T1.sentence_number"
+	switch param.GetSortBy() {
+	case service.SortByUpdatedAt:
+		column = "T1.updated_at"
+	case service.SortByTextLength:
+		column = "T1.text_length"
+	case service.SortByDifficulty:
+		column = "T1.difficulty"
+	}
+	direction := "ASC"
+	if param.GetSortOrder() == service.SortOrderDesc {
+		direction = "DESC"
+	}
+
+	entities := []tatoebaSentencePairEntity{}
+	if result := r.selectPair(param).
+		Order(column + " " + direction + ", T1.sentence_number " + direction + ", T3.sentence_number " + direction).
+		Limit(limit).Offset(offset).Scan(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	results, err := r.toPairModels(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	count, capped, err := r.countTatoebaSentencePairs(ctx, param)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to countTatoebaSentencePairs. err: %w", err)
+	}
+
+	return service.NewTatoebaSentencePairSearchResult(count, capped, results, nil), nil
+}
+
+// randomOrderExpression returns the expression which shuffles source sentences pseudo-randomly by the seed.
+// The sentence number is mixed with the seed by a multiplication and an addition and then raised to the fifth power modulo the prime 2^31-1.
+// Both steps are bijective because 5 does not divide 2^31-2, so different sentences never share a key, and the power makes each seed a different order rather than a rotation of one order.
+// Every product is less than 2^62, so the expression does not overflow 64-bit integers on MySQL and SQLite.
+func randomOrderExpression(seed int) string {
+	x := fmt.Sprintf("((T1.sentence_number * %d + %d) %% %d)", randomMultiplier, seed, randomModulus)
+	x2 := fmt.Sprintf("(%s * %s %% %d)", x, x, randomModulus)
+	x4 := fmt.Sprintf("(%s * %s %% %d)", x2, x2, randomModulus)
+	return fmt.Sprintf("(%s * %s %% %d)", x4, x, randomModulus)
+}
+
+// findTatoebaSentencesByRandom returns the pairs ordered by the random order of the source sentence. The same seed returns the same order across pages
+func (r *tatoebaSentenceRepository) findTatoebaSentencesByRandom(ctx context.Context, param service.TatoebaSentenceSearchCondition) (service.TatoebaSentencePairSearchResult, error) {
+	logger := log.FromContext(ctx)
+	logger.Debug("tatoebaSentenceRepository.FindTatoebaSentencesByRandom")
+	limit := param.GetPageSize()
+	offset := (param.GetPageNo() - 1) * param.GetPageSize()
+
+	entities := []tatoebaSentencePairEntity{}
+	if result := r.selectPair(param).
+		Order(randomOrderExpression(param.GetSeed())).
+		Order("T1.sentence_number, T3.sentence_number").
+		Limit(limit).Offset(offset).Scan(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	results, err := r.toPairModels(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	count, capped, err := r.countTatoebaSentencePairs(ctx, param)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to countTatoebaSentencePairs. err: %w", err)
+	}
+
+	return service.NewTatoebaSentencePairSearchResult(count, capped, results, nil), nil
 }
 
 func (r *tatoebaSentenceRepository) FindTatoebaSentenceBySentenceNumber(ctx context.Context, sentenceNumber int) (service.TatoebaSentence, error) {
 	entity := tatoebaSentenceEntity{}
 	if result := r.db.Where("sentence_number = ?", sentenceNumber).
 		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, liberrors.Errorf("sentenceNumber: %d, err: %w", sentenceNumber, service.ErrTatoebaSentenceNotFound)
+		}
 		return nil, result.Error
 	}
 
-	sentence, err := entity.toModel()
+	audios, err := findTatoebaAudiosBySentenceNumbers(r.db, []int{sentenceNumber})
+	if err != nil {
+		return nil, err
+	}
+
+	sentence, err := entity.toModel(audios)
 	if err != nil {
 		return nil, err
 	}
@@ -303,6 +674,82 @@ func (r *tatoebaSentenceRepository) FindTatoebaSentenceBySentenceNumber(ctx cont
 	return sentence, nil
 }
 
+func (r *tatoebaSentenceRepository) FindTatoebaSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int) ([]service.TatoebaSentence, error) {
+	if len(sentenceNumbers) == 0 {
+		return []service.TatoebaSentence{}, nil
+	}
+
+	entities := []tatoebaSentenceEntity{}
+	if result := r.db.Where("sentence_number IN ?", sentenceNumbers).
+		Find(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	audios, err := findTatoebaAudiosBySentenceNumbers(r.db, sentenceNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]service.TatoebaSentence, len(entities))
+	for i, e := range entities {
+		m, err := e.toModel(audios)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = m
+	}
+
+	return results, nil
+}
+
+func (r *tatoebaSentenceRepository) FindTranslationsBySentenceNumber(ctx context.Context, sentenceNumber int, includeIndirect bool) ([]service.TatoebaSentencePair, error) {
+	db := r.db.Table("tatoeba_sentence AS T1")
+	if includeIndirect {
+		links := r.db.Raw(translationLinkQuery, sentenceNumber, sentenceNumber)
+		db = db.Joins("INNER JOIN (?) AS T2 ON T1.sentence_number = T2.`from`", links).
+			Joins("INNER JOIN tatoeba_sentence AS T3 ON T3.sentence_number = T2.`to`").
+			Joins("LEFT JOIN tatoeba_sentence AS T4 ON T4.sentence_number = T2.pivot_sentence_number").
+			Select(tatoebaSentencePairColumns + "," + tatoebaSentencePivotColumns).
+			Order("T3.lang3, T2.pivot_sentence_number IS NOT NULL, T3.sentence_number")
+	} else {
+		db = db.Joins("INNER JOIN tatoeba_link AS T2 ON T1.sentence_number = T2.`from`").
+			Joins("INNER JOIN tatoeba_sentence AS T3 ON T3.sentence_number = T2.`to`").
+			Select(tatoebaSentencePairColumns).
+			Order("T3.lang3, T3.sentence_number")
+	}
+
+	entities := []tatoebaSentencePairEntity{}
+	if result := db.Where("T1.sentence_number = ?", sentenceNumber).Scan(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	results, err := r.toPairModels(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *tatoebaSentenceRepository) FindTatoebaSentencesByLang3(ctx context.Context, lang3 domain.Lang3, afterSentenceNumber, limit int) ([]service.TatoebaSentence, error) {
+	entities := []tatoebaSentenceEntity{}
+	if result := r.db.Where("lang3 = ? AND sentence_number > ?", lang3.String(), afterSentenceNumber).
+		Order("sentence_number").Limit(limit).Find(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	results := make([]service.TatoebaSentence, len(entities))
+	for i, e := range entities {
+		m, err := e.toModel(nil)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = m
+	}
+
+	return results, nil
+}
+
 func (r *tatoebaSentenceRepository) ContainsSentenceBySentenceNumber(ctx context.Context, sentenceNumber int) (bool, error) {
 	entity := tatoebaSentenceEntity{}
 	if result := r.db.Where("sentence_number = ?", sentenceNumber).
@@ -316,14 +763,42 @@ func (r *tatoebaSentenceRepository) ContainsSentenceBySentenceNumber(ctx context
 	return true, nil
 }
 
-func (r *tatoebaSentenceRepository) Add(ctx context.Context, param service.TatoebaSentenceAddParameter) error {
-	entity := tatoebaSentenceEntity{
+func (r *tatoebaSentenceRepository) ContainsSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int, lang3s []domain.Lang3) (map[int]bool, error) {
+	contained := make(map[int]bool)
+	if len(sentenceNumbers) == 0 {
+		return contained, nil
+	}
+
+	db := r.db.Model(&tatoebaSentenceEntity{}).Where("sentence_number IN ?", sentenceNumbers)
+	db = whereLang3s(db, lang3s)
+
+	found := make([]int, 0)
+	if result := db.Pluck("sentence_number", &found); result.Error != nil {
+		return nil, liberrors.Errorf("failed to ContainsSentencesBySentenceNumbers. err: %w", result.Error)
+	}
+
+	for _, sentenceNumber := range found {
+		contained[sentenceNumber] = true
+	}
+
+	return contained, nil
+}
+
+func toTatoebaSentenceEntity(param service.TatoebaSentenceAddParameter) tatoebaSentenceEntity {
+	return tatoebaSentenceEntity{
 		SentenceNumber: param.GetSentenceNumber(),
 		Lang3:          param.GetLang3().String(),
 		Text:           param.GetText(),
 		Author:         param.GetAuthor(),
 		UpdatedAt:      param.GetUpdatedAt(),
+		TextLength:     utf8.RuneCountInString(param.GetText()),
+		WordCount:      domain.CountWords(param.GetText()),
+		License:        string(service.TatoebaSentenceLicenseCCBY20FR),
 	}
+}
+
+func (r *tatoebaSentenceRepository) Add(ctx context.Context, param service.TatoebaSentenceAddParameter) error {
+	entity := toTatoebaSentenceEntity(param)
 
 	if result := r.db.Create(&entity); result.Error != nil {
 		err := libG.ConvertDuplicatedError(result.Error, service.ErrTatoebaSentenceAlreadyExists)
@@ -332,3 +807,235 @@ func (r *tatoebaSentenceRepository) Add(ctx context.Context, param service.Tatoe
 
 	return nil
 }
+
+func (r *tatoebaSentenceRepository) AddBatch(ctx context.Context, params []service.TatoebaSentenceAddParameter) (int, error) {
+	if len(params) == 0 {
+		return 0, nil
+	}
+
+	entities := make([]tatoebaSentenceEntity, len(params))
+	for i, param := range params {
+		entities[i] = toTatoebaSentenceEntity(param)
+	}
+
+	result := r.db.Clauses(insertIgnoreClause(r.driverName)).CreateInBatches(&entities, bulkInsertSize)
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to AddBatch tatoebaSentence. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaSentenceRepository) SyncBatch(ctx context.Context, params []service.TatoebaSentenceAddParameter) ([]service.TatoebaSentenceSyncResult, error) {
+	return r.syncBatch(ctx, params, false)
+}
+
+func (r *tatoebaSentenceRepository) PreviewSyncBatch(ctx context.Context, params []service.TatoebaSentenceAddParameter) ([]service.TatoebaSentenceSyncResult, error) {
+	return r.syncBatch(ctx, params, true)
+}
+
+// syncBatch compares the sentences with the stored ones found in one query. The new sentences are added with a multi-row insert and the changed sentences are updated one by one.
+// A sentence appearing twice in the batch is compared with its previous occurrence
+func (r *tatoebaSentenceRepository) syncBatch(ctx context.Context, params []service.TatoebaSentenceAddParameter, dryRun bool) ([]service.TatoebaSentenceSyncResult, error) {
+	results := make([]service.TatoebaSentenceSyncResult, len(params))
+	if len(params) == 0 {
+		return results, nil
+	}
+
+	sentenceNumbers := make([]int, len(params))
+	for i, param := range params {
+		sentenceNumbers[i] = param.GetSentenceNumber()
+	}
+
+	stored := []tatoebaSentenceEntity{}
+	if result := r.db.Where("sentence_number IN ?", sentenceNumbers).
+		Find(&stored); result.Error != nil {
+		return nil, liberrors.Errorf("failed to find tatoebaSentences. err: %w", result.Error)
+	}
+
+	entities := make(map[int]tatoebaSentenceEntity, len(stored))
+	for _, e := range stored {
+		entities[e.SentenceNumber] = e
+	}
+
+	inserted := make([]service.TatoebaSentenceAddParameter, 0)
+	updated := make([]service.TatoebaSentenceAddParameter, 0)
+	for i, param := range params {
+		entity, ok := entities[param.GetSentenceNumber()]
+		results[i] = toSyncResult(entity, ok, param)
+		switch results[i] {
+		case service.TatoebaSentenceInserted:
+			inserted = append(inserted, param)
+		case service.TatoebaSentenceUpdated:
+			updated = append(updated, param)
+		default:
+			continue
+		}
+		entities[param.GetSentenceNumber()] = toTatoebaSentenceEntity(param)
+	}
+
+	if dryRun {
+		return results, nil
+	}
+
+	if _, err := r.AddBatch(ctx, inserted); err != nil {
+		return nil, err
+	}
+
+	for _, param := range updated {
+		if result := r.db.Model(&tatoebaSentenceEntity{}).Where("sentence_number = ?", param.GetSentenceNumber()).
+			UpdateColumns(map[string]interface{}{
+				"lang3":       param.GetLang3().String(),
+				"text":        param.GetText(),
+				"author":      param.GetAuthor(),
+				"updated_at":  param.GetUpdatedAt(),
+				"text_length": utf8.RuneCountInString(param.GetText()),
+				"word_count":  domain.CountWords(param.GetText()),
+			}); result.Error != nil {
+			return nil, liberrors.Errorf("failed to update tatoebaSentence. err: %w", result.Error)
+		}
+	}
+
+	return results, nil
+}
+
+// toSyncResult compares the sentence with the stored one.
+// updated_at of the parameter is the date last modified of the dump, see tatoebaSentenceAddParameterReader, so an edited sentence is newer than the stored one.
+// The stored sentence is kept if the dump is older. Rows without dates are compared by their contents
+func toSyncResult(entity tatoebaSentenceEntity, stored bool, param service.TatoebaSentenceAddParameter) service.TatoebaSentenceSyncResult {
+	if !stored {
+		return service.TatoebaSentenceInserted
+	}
+	if !param.GetUpdatedAt().After(entity.UpdatedAt) {
+		return service.TatoebaSentenceUnchanged
+	}
+	if entity.Lang3 == param.GetLang3().String() && entity.Text == param.GetText() && entity.Author == param.GetAuthor() {
+		return service.TatoebaSentenceUnchanged
+	}
+
+	return service.TatoebaSentenceUpdated
+}
+
+func (r *tatoebaSentenceRepository) RemoveTatoebaSentences(ctx context.Context, sentenceNumbers []int) (int, error) {
+	if len(sentenceNumbers) == 0 {
+		return 0, nil
+	}
+
+	if result := r.db.Where("`from` IN ? OR `to` IN ?", sentenceNumbers, sentenceNumbers).
+		Delete(&tatoebaLinkEntity{}); result.Error != nil {
+		return 0, liberrors.Errorf("failed to remove tatoebaLinks. err: %w", result.Error)
+	}
+
+	if result := r.db.Where("sentence_number IN ?", sentenceNumbers).
+		Delete(&tatoebaTagEntity{}); result.Error != nil {
+		return 0, liberrors.Errorf("failed to remove tatoebaTags. err: %w", result.Error)
+	}
+
+	if result := r.db.Where("sentence_number IN ?", sentenceNumbers).
+		Delete(&tatoebaAudioEntity{}); result.Error != nil {
+		return 0, liberrors.Errorf("failed to remove tatoebaAudios. err: %w", result.Error)
+	}
+
+	result := r.db.Where("sentence_number IN ?", sentenceNumbers).Delete(&tatoebaSentenceEntity{})
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to remove tatoebaSentences. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// UpdateDifficultiesAndWordCounts sets both columns with CASE expressions keyed by sentence number so that the page of sentences is updated in one statement
+func (r *tatoebaSentenceRepository) UpdateDifficultiesAndWordCounts(ctx context.Context, params []service.TatoebaSentenceDifficultyParameter) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	sentenceNumbers := make([]int, len(params))
+	difficultyArgs := make([]interface{}, 0, len(params)*2)
+	wordCountArgs := make([]interface{}, 0, len(params)*2)
+	for i, param := range params {
+		sentenceNumbers[i] = param.GetSentenceNumber()
+		difficultyArgs = append(difficultyArgs, param.GetSentenceNumber(), param.GetDifficulty())
+		wordCountArgs = append(wordCountArgs, param.GetSentenceNumber(), param.GetWordCount())
+	}
+	caseExpression := "CASE sentence_number" + strings.Repeat(" WHEN ? THEN ?", len(params)) + " END"
+
+	if result := r.db.Model(&tatoebaSentenceEntity{}).Where("sentence_number IN ?", sentenceNumbers).
+		UpdateColumns(map[string]interface{}{
+			"difficulty": gorm.Expr(caseExpression, difficultyArgs...),
+			"word_count": gorm.Expr(caseExpression, wordCountArgs...),
+		}); result.Error != nil {
+		return liberrors.Errorf("failed to UpdateDifficultiesAndWordCounts. err: %w", result.Error)
+	}
+
+	return nil
+}
+
+func (r *tatoebaSentenceRepository) UpdateLicenses(ctx context.Context, params []service.TatoebaSentenceLicenseParameter, lang3s []domain.Lang3) (int, error) {
+	updateCount := 0
+	for license, sentenceNumbers := range groupSentenceNumbersByLicense(params) {
+		result := r.sentencesToRelicense(license, sentenceNumbers, lang3s).
+			UpdateColumn("license", string(license))
+		if result.Error != nil {
+			return 0, liberrors.Errorf("failed to UpdateLicenses. err: %w", result.Error)
+		}
+		updateCount += int(result.RowsAffected)
+	}
+
+	return updateCount, nil
+}
+
+func (r *tatoebaSentenceRepository) CountLicenseUpdates(ctx context.Context, params []service.TatoebaSentenceLicenseParameter, lang3s []domain.Lang3) (int, error) {
+	updateCount := 0
+	for license, sentenceNumbers := range groupSentenceNumbersByLicense(params) {
+		var count int64
+		if result := r.sentencesToRelicense(license, sentenceNumbers, lang3s).Count(&count); result.Error != nil {
+			return 0, liberrors.Errorf("failed to CountLicenseUpdates. err: %w", result.Error)
+		}
+		updateCount += int(count)
+	}
+
+	return updateCount, nil
+}
+
+// sentencesToRelicense returns the query of the sentences of lang3s among the sentence numbers which have a license other than the license
+func (r *tatoebaSentenceRepository) sentencesToRelicense(license service.TatoebaSentenceLicense, sentenceNumbers []int, lang3s []domain.Lang3) *gorm.DB {
+	db := r.db.Model(&tatoebaSentenceEntity{}).
+		Where("sentence_number IN ? AND license <> ?", sentenceNumbers, string(license))
+	return whereLang3s(db, lang3s)
+}
+
+func groupSentenceNumbersByLicense(params []service.TatoebaSentenceLicenseParameter) map[service.TatoebaSentenceLicense][]int {
+	sentenceNumbers := make(map[service.TatoebaSentenceLicense][]int)
+	for _, param := range params {
+		sentenceNumbers[param.GetLicense()] = append(sentenceNumbers[param.GetLicense()], param.GetSentenceNumber())
+	}
+	return sentenceNumbers
+}
+
+func (r *tatoebaSentenceRepository) FindSentenceNumbersByLicense(ctx context.Context, license service.TatoebaSentenceLicense, lang3s []domain.Lang3, afterSentenceNumber, limit int) ([]int, error) {
+	db := r.db.Model(&tatoebaSentenceEntity{}).
+		Where("license = ? AND sentence_number > ?", string(license), afterSentenceNumber)
+	db = whereLang3s(db, lang3s)
+
+	sentenceNumbers := make([]int, 0)
+	if result := db.Order("sentence_number").Limit(limit).
+		Pluck("sentence_number", &sentenceNumbers); result.Error != nil {
+		return nil, liberrors.Errorf("failed to FindSentenceNumbersByLicense. err: %w", result.Error)
+	}
+
+	return sentenceNumbers, nil
+}
+
+// whereLang3s restricts the sentences to those of lang3s unless lang3s is empty
+func whereLang3s(db *gorm.DB, lang3s []domain.Lang3) *gorm.DB {
+	if len(lang3s) == 0 {
+		return db
+	}
+
+	languages := make([]string, len(lang3s))
+	for i, lang3 := range lang3s {
+		languages[i] = lang3.String()
+	}
+	return db.Where("lang3 IN ?", languages)
+}
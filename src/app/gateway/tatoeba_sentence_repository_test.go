@@ -1,10 +1,17 @@
 package gateway_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 )
 
 func Test_tatoebaSentenceRepository_FindTatoebaSentences(t *testing.T) {
@@ -17,3 +24,378 @@ func Test_tatoebaSentenceRepository_FindTatoebaSentences(t *testing.T) {
 		defer sqlDB.Close()
 	}
 }
+
+func Test_tatoebaSentenceRepository_FindTatoebaSentencePairs_random(t *testing.T) {
+	sentences := make([]testSentence, 0)
+	links := make([][2]int, 0)
+	for i := 1; i <= 10; i++ {
+		sentences = append(sentences,
+			testSentence{sentenceNumber: i, lang3: "eng", text: "eng"},
+			testSentence{sentenceNumber: 100 + i, lang3: "jpn", text: "jpn"})
+		links = append(links, [2]int{i, 100 + i})
+	}
+	sentences = append(sentences, testSentence{sentenceNumber: 201, lang3: "jpn", text: "jpn"})
+	links = append(links, [2]int{1, 201})
+	const pairCount = 11
+
+	findPairs := func(t *testing.T, repo service.TatoebaSentenceRepository, seed, pageNo, pageSize int) [][2]int {
+		cond, err := service.NewTatoebaSentenceSearchCondition(pageNo, pageSize, nil, true, seed, newLang3(t, "eng"), newLang3(t, "jpn"), 1, nil, nil, service.SortBySentenceNumber, service.SortOrderAsc, nil)
+		require.NoError(t, err)
+
+		result, err := repo.FindTatoebaSentencePairs(context.Background(), cond)
+		require.NoError(t, err)
+		assert.Equal(t, pairCount, result.GetTotalCount())
+
+		pairs := make([][2]int, 0)
+		for _, pair := range result.GetResults() {
+			pairs = append(pairs, [2]int{pair.GetSrc().GetSentenceNumber(), pair.GetDst().GetSentenceNumber()})
+		}
+		return pairs
+	}
+
+	for driverName, db := range dbList() {
+		truncateTables(t, db)
+		addSentences(t, db, driverName, sentences, links)
+		repo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+		require.NoError(t, err)
+
+		orders := make([][]int, 0)
+		for _, seed := range []int{0, 1 << 30, service.MaxSeed} {
+			seed := seed
+			t.Run(driverName, func(t *testing.T) {
+				all := findPairs(t, repo, seed, 1, 100)
+				assert.Len(t, all, pairCount)
+				orders = append(orders, srcOrder(all))
+
+				// the pages follow the same order without duplicates or gaps
+				paged := make([][2]int, 0)
+				for pageNo := 1; pageNo <= 5; pageNo++ {
+					paged = append(paged, findPairs(t, repo, seed, pageNo, 3)...)
+				}
+				assert.Equal(t, all, paged)
+
+				unique := make(map[[2]int]bool)
+				for _, pair := range all {
+					unique[pair] = true
+				}
+				assert.Len(t, unique, pairCount)
+			})
+		}
+
+		// each seed shuffles the sentences in a different order, not in a rotation of one order
+		for i := 1; i < len(orders); i++ {
+			assert.False(t, isRotation(orders[0], orders[i]), "%v, %v", orders[0], orders[i])
+		}
+	}
+}
+
+// srcOrder returns the source sentence numbers in the order of the pairs
+func srcOrder(pairs [][2]int) []int {
+	order := make([]int, 0)
+	for i, pair := range pairs {
+		if i == 0 || pairs[i-1][0] != pair[0] {
+			order = append(order, pair[0])
+		}
+	}
+	return order
+}
+
+func isRotation(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for shift := range a {
+		rotated := append(append([]int{}, a[shift:]...), a[:shift]...)
+		if assert.ObjectsAreEqual(rotated, b) {
+			return true
+		}
+	}
+	return false
+}
+
+func Test_tatoebaSentenceRepository_SyncBatch(t *testing.T) {
+	ctx := context.Background()
+	stored := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	older := stored.Add(-time.Hour)
+	newer := stored.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		sentence  testSentence
+		updatedAt time.Time
+		want      service.TatoebaSentenceSyncResult
+		wantText  string
+	}{
+		{name: "new", sentence: testSentence{sentenceNumber: 3, lang3: "eng", text: "new"}, updatedAt: newer, want: service.TatoebaSentenceInserted, wantText: "new"},
+		{name: "changed and newer", sentence: testSentence{sentenceNumber: 1, lang3: "eng", text: "edited"}, updatedAt: newer, want: service.TatoebaSentenceUpdated, wantText: "edited"},
+		{name: "changed but older", sentence: testSentence{sentenceNumber: 1, lang3: "eng", text: "edited"}, updatedAt: older, want: service.TatoebaSentenceUnchanged, wantText: "one"},
+		{name: "changed but as old", sentence: testSentence{sentenceNumber: 1, lang3: "eng", text: "edited"}, updatedAt: stored, want: service.TatoebaSentenceUnchanged, wantText: "one"},
+		{name: "unchanged and newer", sentence: testSentence{sentenceNumber: 2, lang3: "eng", text: "two"}, updatedAt: newer, want: service.TatoebaSentenceUnchanged, wantText: "two"},
+	}
+
+	for driverName, db := range dbList() {
+		repo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+		require.NoError(t, err)
+
+		for _, tt := range tests {
+			t.Run(driverName+"/"+tt.name, func(t *testing.T) {
+				for _, dryRun := range []bool{true, false} {
+					truncateTables(t, db)
+					addSentences(t, db, driverName, []testSentence{
+						{sentenceNumber: 1, lang3: "eng", text: "one"},
+						{sentenceNumber: 2, lang3: "eng", text: "two"},
+					}, nil)
+
+					syncBatch := repo.SyncBatch
+					if dryRun {
+						syncBatch = repo.PreviewSyncBatch
+					}
+					results, err := syncBatch(ctx, []service.TatoebaSentenceAddParameter{newSentenceAddParameter(t, tt.sentence, tt.updatedAt)})
+					require.NoError(t, err)
+					assert.Equal(t, []service.TatoebaSentenceSyncResult{tt.want}, results)
+
+					sentence, err := repo.FindTatoebaSentenceBySentenceNumber(ctx, tt.sentence.sentenceNumber)
+					if dryRun && tt.want == service.TatoebaSentenceInserted {
+						assert.ErrorIs(t, err, service.ErrTatoebaSentenceNotFound)
+						continue
+					}
+					require.NoError(t, err)
+					if dryRun {
+						assert.NotEqual(t, "edited", sentence.GetText())
+						continue
+					}
+					assert.Equal(t, tt.wantText, sentence.GetText())
+				}
+			})
+		}
+
+		t.Run(driverName+"/twice in a batch", func(t *testing.T) {
+			truncateTables(t, db)
+			results, err := repo.SyncBatch(ctx, []service.TatoebaSentenceAddParameter{
+				newSentenceAddParameter(t, testSentence{sentenceNumber: 1, lang3: "eng", text: "first"}, stored),
+				newSentenceAddParameter(t, testSentence{sentenceNumber: 1, lang3: "eng", text: "second"}, newer),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []service.TatoebaSentenceSyncResult{service.TatoebaSentenceInserted, service.TatoebaSentenceUpdated}, results)
+
+			sentence, err := repo.FindTatoebaSentenceBySentenceNumber(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "second", sentence.GetText())
+		})
+	}
+}
+
+func Test_tatoebaSentenceRepository_AddBatch(t *testing.T) {
+	ctx := context.Background()
+	updatedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for driverName, db := range dbList() {
+		t.Run(driverName, func(t *testing.T) {
+			truncateTables(t, db)
+			addSentences(t, db, driverName, []testSentence{{sentenceNumber: 1, lang3: "eng", text: "one"}}, nil)
+			repo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+			require.NoError(t, err)
+
+			// the existing sentence and the second occurrence in the batch are ignored
+			count, err := repo.AddBatch(ctx, []service.TatoebaSentenceAddParameter{
+				newSentenceAddParameter(t, testSentence{sentenceNumber: 1, lang3: "eng", text: "edited"}, updatedAt),
+				newSentenceAddParameter(t, testSentence{sentenceNumber: 2, lang3: "eng", text: "two"}, updatedAt),
+				newSentenceAddParameter(t, testSentence{sentenceNumber: 2, lang3: "eng", text: "again"}, updatedAt),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			for sentenceNumber, text := range map[int]string{1: "one", 2: "two"} {
+				sentence, err := repo.FindTatoebaSentenceBySentenceNumber(ctx, sentenceNumber)
+				require.NoError(t, err)
+				assert.Equal(t, text, sentence.GetText())
+			}
+		})
+	}
+}
+
+func Test_tatoebaSentenceRepository_FindTatoebaSentencePairs_filter(t *testing.T) {
+	ctx := context.Background()
+
+	newFilter := func(t *testing.T, includeTags, excludeTags []string, withAudioOnly bool, license service.TatoebaSentenceLicense) service.TatoebaSentenceFilter {
+		filter, err := service.NewTatoebaSentenceFilter(0, 100, 0, 0, 0, 0, nil, nil, includeTags, excludeTags, withAudioOnly, license, nil, nil)
+		require.NoError(t, err)
+		return filter
+	}
+
+	tests := []struct {
+		name          string
+		includeTags   []string
+		excludeTags   []string
+		withAudioOnly bool
+		license       service.TatoebaSentenceLicense
+		want          []int
+	}{
+		{name: "no filter", want: []int{1, 2, 3, 4}},
+		{name: "include tags", includeTags: []string{"a", "b"}, want: []int{1, 2}},
+		{name: "exclude tags", excludeTags: []string{"a"}, want: []int{2, 3, 4}},
+		{name: "include and exclude tags", includeTags: []string{"b"}, excludeTags: []string{"a"}, want: []int{2}},
+		{name: "with audio only", withAudioOnly: true, want: []int{3}},
+		{name: "CC0", license: service.TatoebaSentenceLicenseCC0, want: []int{4}},
+		{name: "CC BY 2.0 FR", license: service.TatoebaSentenceLicenseCCBY20FR, want: []int{1, 2, 3}},
+	}
+
+	for driverName, db := range dbList() {
+		truncateTables(t, db)
+		addSentences(t, db, driverName, []testSentence{
+			{sentenceNumber: 1, lang3: "eng", text: "one"},
+			{sentenceNumber: 2, lang3: "eng", text: "two"},
+			{sentenceNumber: 3, lang3: "eng", text: "three"},
+			{sentenceNumber: 4, lang3: "eng", text: "four"},
+			{sentenceNumber: 101, lang3: "jpn", text: "ichi"},
+			{sentenceNumber: 102, lang3: "jpn", text: "ni"},
+			{sentenceNumber: 103, lang3: "jpn", text: "san"},
+			{sentenceNumber: 104, lang3: "jpn", text: "yon"},
+		}, [][2]int{{1, 101}, {2, 102}, {3, 103}, {4, 104}})
+
+		// 1 has the tags a and b, 2 has b, 3 has an audio and 4 is CC0
+		tagRepo, err := gateway.NewTatoebaTagRepository(db, driverName)
+		require.NoError(t, err)
+		tags := make([]service.TatoebaTagAddParameter, 0)
+		for _, tag := range []struct {
+			sentenceNumber int
+			tagName        string
+		}{{1, "a"}, {1, "b"}, {2, "b"}} {
+			param, err := service.NewTatoebaTagAddParameter(tag.sentenceNumber, tag.tagName)
+			require.NoError(t, err)
+			tags = append(tags, param)
+		}
+		_, err = tagRepo.AddBatch(ctx, tags, nil)
+		require.NoError(t, err)
+
+		audioRepo, err := gateway.NewTatoebaAudioRepository(db, driverName)
+		require.NoError(t, err)
+		audio, err := service.NewTatoebaAudioAddParameter(10, 3, "alice", "", "")
+		require.NoError(t, err)
+		_, err = audioRepo.AddBatch(ctx, []service.TatoebaAudioAddParameter{audio}, nil)
+		require.NoError(t, err)
+
+		repo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+		require.NoError(t, err)
+		license, err := service.NewTatoebaSentenceLicenseParameter(4, service.TatoebaSentenceLicenseCC0)
+		require.NoError(t, err)
+		_, err = repo.UpdateLicenses(ctx, []service.TatoebaSentenceLicenseParameter{license}, nil)
+		require.NoError(t, err)
+
+		for _, tt := range tests {
+			t.Run(driverName+"/"+tt.name, func(t *testing.T) {
+				filter := newFilter(t, tt.includeTags, tt.excludeTags, tt.withAudioOnly, tt.license)
+				assert.Equal(t, tt.want, findSrcSentenceNumbers(t, repo, "eng", "jpn", nil, filter))
+			})
+		}
+	}
+}
+
+func Test_tatoebaSentenceRepository_UpdateLicenses(t *testing.T) {
+	ctx := context.Background()
+
+	newLicenseParameters := func(t *testing.T, license service.TatoebaSentenceLicense, sentenceNumbers ...int) []service.TatoebaSentenceLicenseParameter {
+		params := make([]service.TatoebaSentenceLicenseParameter, len(sentenceNumbers))
+		for i, sentenceNumber := range sentenceNumbers {
+			param, err := service.NewTatoebaSentenceLicenseParameter(sentenceNumber, license)
+			require.NoError(t, err)
+			params[i] = param
+		}
+		return params
+	}
+
+	tests := []struct {
+		name   string
+		lang3s []string
+		want   []int
+	}{
+		{name: "all languages", want: []int{1, 3}},
+		{name: "eng", lang3s: []string{"eng"}, want: []int{1}},
+	}
+
+	for driverName, db := range dbList() {
+		repo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+		require.NoError(t, err)
+
+		for _, tt := range tests {
+			t.Run(driverName+"/"+tt.name, func(t *testing.T) {
+				lang3s := make([]domain.Lang3, len(tt.lang3s))
+				for i, lang3 := range tt.lang3s {
+					lang3s[i] = newLang3(t, lang3)
+				}
+
+				for _, dryRun := range []bool{true, false} {
+					truncateTables(t, db)
+					addSentences(t, db, driverName, []testSentence{
+						{sentenceNumber: 1, lang3: "eng", text: "one"},
+						{sentenceNumber: 2, lang3: "eng", text: "two"},
+						{sentenceNumber: 3, lang3: "jpn", text: "san"},
+					}, nil)
+
+					// sentences which do not exist are skipped
+					params := newLicenseParameters(t, service.TatoebaSentenceLicenseCC0, 1, 3, 9)
+					updateLicenses := repo.UpdateLicenses
+					if dryRun {
+						updateLicenses = repo.CountLicenseUpdates
+					}
+					count, err := updateLicenses(ctx, params, lang3s)
+					require.NoError(t, err)
+					assert.Equal(t, len(tt.want), count)
+
+					sentenceNumbers, err := repo.FindSentenceNumbersByLicense(ctx, service.TatoebaSentenceLicenseCC0, nil, 0, 10)
+					require.NoError(t, err)
+					if dryRun {
+						// a dry run does not write the licenses
+						assert.Empty(t, sentenceNumbers)
+						continue
+					}
+					assert.Equal(t, tt.want, sentenceNumbers)
+
+					// sentences which already have the license are skipped
+					count, err = repo.UpdateLicenses(ctx, params, lang3s)
+					require.NoError(t, err)
+					assert.Equal(t, 0, count)
+
+					// the licenses are given back
+					count, err = repo.UpdateLicenses(ctx, newLicenseParameters(t, service.TatoebaSentenceLicenseCCBY20FR, tt.want...), nil)
+					require.NoError(t, err)
+					assert.Equal(t, len(tt.want), count)
+				}
+			})
+		}
+	}
+}
+
+func Test_tatoebaSentenceRepository_UpdateDifficultiesAndWordCounts(t *testing.T) {
+	ctx := context.Background()
+
+	for driverName, db := range dbList() {
+		t.Run(driverName, func(t *testing.T) {
+			truncateTables(t, db)
+			addSentences(t, db, driverName, []testSentence{
+				{sentenceNumber: 1, lang3: "eng", text: "one"},
+				{sentenceNumber: 2, lang3: "eng", text: "two"},
+				{sentenceNumber: 3, lang3: "eng", text: "three"},
+			}, nil)
+			repo, err := gateway.NewTatoebaSentenceRepository(db, driverName)
+			require.NoError(t, err)
+
+			params := make([]service.TatoebaSentenceDifficultyParameter, 0)
+			for _, p := range [][3]int{{1, 10, 4}, {3, 30, 6}} {
+				param, err := service.NewTatoebaSentenceDifficultyParameter(p[0], p[1], p[2])
+				require.NoError(t, err)
+				params = append(params, param)
+			}
+			require.NoError(t, repo.UpdateDifficultiesAndWordCounts(ctx, params))
+
+			sentences, err := repo.FindTatoebaSentencesBySentenceNumbers(ctx, []int{1, 2, 3})
+			require.NoError(t, err)
+			got := make(map[int][2]int)
+			for _, sentence := range sentences {
+				got[sentence.GetSentenceNumber()] = [2]int{sentence.GetDifficulty(), sentence.GetWordCount()}
+			}
+			// the sentence which is not in the parameters keeps its values
+			assert.Equal(t, map[int][2]int{1: {10, 4}, 2: {0, 1}, 3: {30, 6}}, got)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+const tagColumnCount = 2
+
+type tatoebaTagAddParameterReader struct {
+	reader *tsvRowReader
+}
+
+// NewTatoebaTagAddParameterReader returns the iterator of tags. The input can be compressed with gzip or bzip2 and archived with tar
+func NewTatoebaTagAddParameterReader(reader io.Reader) service.TatoebaTagAddParameterIterator {
+	return &tatoebaTagAddParameterReader{
+		reader: newTSVRowReader(reader, tagColumnCount),
+	}
+}
+
+// Next parses a row of tags.csv, which has the sentence number and the tag name
+func (r *tatoebaTagAddParameterReader) Next(ctx context.Context) (service.TatoebaTagAddParameter, error) {
+	var param service.TatoebaTagAddParameter
+	if err := r.reader.next(func(columns []string) error {
+		tmpParam, err := r.parse(columns)
+		param = tmpParam
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return param, nil
+}
+
+func (r *tatoebaTagAddParameterReader) parse(columns []string) (service.TatoebaTagAddParameter, error) {
+	sentenceNumber, err := strconv.Atoi(columns[0])
+	if err != nil || sentenceNumber <= 0 {
+		return nil, fmt.Errorf("invalid sentence number. value: %s", truncateRunes(columns[0], maxReasonValueLength))
+	}
+
+	tagName := strings.TrimSpace(columns[1])
+	if tagName == "" {
+		return nil, errors.New("empty tag name")
+	}
+
+	param, err := service.NewTatoebaTagAddParameter(sentenceNumber, tagName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag. value: %s", truncateRunes(tagName, maxReasonValueLength))
+	}
+
+	return param, nil
+}
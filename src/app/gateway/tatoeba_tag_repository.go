@@ -0,0 +1,168 @@
+package gateway
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
+)
+
+type tatoebaTagRepository struct {
+	db           *gorm.DB
+	driverName   string
+	sentenceRepo service.TatoebaSentenceRepository
+}
+
+type tatoebaTagEntity struct {
+	SentenceNumber int
+	TagName        string
+}
+
+func (e *tatoebaTagEntity) TableName() string {
+	return "tatoeba_tag"
+}
+
+func NewTatoebaTagRepository(db *gorm.DB, driverName string) (service.TatoebaTagRepository, error) {
+	sentenceRepo, err := NewTatoebaSentenceRepository(db, driverName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tatoebaTagRepository{
+		db:           db,
+		driverName:   driverName,
+		sentenceRepo: sentenceRepo,
+	}, nil
+}
+
+func (r *tatoebaTagRepository) AddBatch(ctx context.Context, params []service.TatoebaTagAddParameter, lang3s []domain.Lang3) (int, error) {
+	entities, err := r.taggableEntities(ctx, params, lang3s)
+	if err != nil {
+		return 0, err
+	}
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.Clauses(insertIgnoreClause(r.driverName)).CreateInBatches(&entities, bulkInsertSize)
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to AddBatch tatoebaTag. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaTagRepository) CountNewTags(ctx context.Context, params []service.TatoebaTagAddParameter, lang3s []domain.Lang3) (int, error) {
+	entities, err := r.taggableEntities(ctx, params, lang3s)
+	if err != nil {
+		return 0, err
+	}
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	sentenceNumbers := make([]int, len(entities))
+	for i, entity := range entities {
+		sentenceNumbers[i] = entity.SentenceNumber
+	}
+
+	existingEntities := make([]tatoebaTagEntity, 0)
+	if result := r.db.Where("sentence_number IN ?", sentenceNumbers).Find(&existingEntities); result.Error != nil {
+		return 0, liberrors.Errorf("failed to find tatoebaTags. err: %w", result.Error)
+	}
+
+	tags := make(map[tatoebaTagEntity]bool)
+	for _, entity := range existingEntities {
+		tags[entity] = true
+	}
+
+	count := 0
+	for _, entity := range entities {
+		if !tags[entity] {
+			tags[entity] = true
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// taggableEntities returns the tags whose sentences exist and are of lang3s unless lang3s is empty
+func (r *tatoebaTagRepository) taggableEntities(ctx context.Context, params []service.TatoebaTagAddParameter, lang3s []domain.Lang3) ([]tatoebaTagEntity, error) {
+	sentenceNumbers := make([]int, len(params))
+	for i, param := range params {
+		sentenceNumbers[i] = param.GetSentenceNumber()
+	}
+
+	contained, err := r.sentenceRepo.ContainsSentencesBySentenceNumbers(ctx, sentenceNumbers, lang3s)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]tatoebaTagEntity, 0, len(params))
+	for _, param := range params {
+		if contained[param.GetSentenceNumber()] {
+			entities = append(entities, tatoebaTagEntity{
+				SentenceNumber: param.GetSentenceNumber(),
+				TagName:        param.GetTagName(),
+			})
+		}
+	}
+
+	return entities, nil
+}
+
+func (r *tatoebaTagRepository) RemoveTagsBySentenceNumber(ctx context.Context, sentenceNumber int, keptTagNames []string) (int, error) {
+	result := r.tagsBySentenceNumber(sentenceNumber, keptTagNames).Delete(&tatoebaTagEntity{})
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to RemoveTagsBySentenceNumber. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaTagRepository) CountTagsBySentenceNumber(ctx context.Context, sentenceNumber int, keptTagNames []string) (int, error) {
+	var count int64
+	if result := r.tagsBySentenceNumber(sentenceNumber, keptTagNames).Model(&tatoebaTagEntity{}).Count(&count); result.Error != nil {
+		return 0, liberrors.Errorf("failed to CountTagsBySentenceNumber. err: %w", result.Error)
+	}
+
+	return int(count), nil
+}
+
+func (r *tatoebaTagRepository) tagsBySentenceNumber(sentenceNumber int, keptTagNames []string) *gorm.DB {
+	db := r.db.Where("sentence_number = ?", sentenceNumber)
+	if len(keptTagNames) > 0 {
+		db = db.Where("tag_name NOT IN ?", keptTagNames)
+	}
+	return db
+}
+
+func (r *tatoebaTagRepository) RemoveTagsBySentenceNumberRange(ctx context.Context, afterSentenceNumber, beforeSentenceNumber int) (int, error) {
+	result := r.tagsBySentenceNumberRange(afterSentenceNumber, beforeSentenceNumber).Delete(&tatoebaTagEntity{})
+	if result.Error != nil {
+		return 0, liberrors.Errorf("failed to RemoveTagsBySentenceNumberRange. err: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func (r *tatoebaTagRepository) CountTagsBySentenceNumberRange(ctx context.Context, afterSentenceNumber, beforeSentenceNumber int) (int, error) {
+	var count int64
+	if result := r.tagsBySentenceNumberRange(afterSentenceNumber, beforeSentenceNumber).Model(&tatoebaTagEntity{}).Count(&count); result.Error != nil {
+		return 0, liberrors.Errorf("failed to CountTagsBySentenceNumberRange. err: %w", result.Error)
+	}
+
+	return int(count), nil
+}
+
+func (r *tatoebaTagRepository) tagsBySentenceNumberRange(afterSentenceNumber, beforeSentenceNumber int) *gorm.DB {
+	db := r.db.Where("sentence_number > ?", afterSentenceNumber)
+	if beforeSentenceNumber > 0 {
+		db = db.Where("sentence_number < ?", beforeSentenceNumber)
+	}
+	return db
+}
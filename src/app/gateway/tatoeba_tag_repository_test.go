@@ -0,0 +1,84 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+func Test_tatoebaTagRepository_AddBatch(t *testing.T) {
+	ctx := context.Background()
+
+	newTagAddParameter := func(t *testing.T, sentenceNumber int, tagName string) service.TatoebaTagAddParameter {
+		param, err := service.NewTatoebaTagAddParameter(sentenceNumber, tagName)
+		require.NoError(t, err)
+		return param
+	}
+
+	tests := []struct {
+		name      string
+		lang3s    []string
+		want      int
+		wantCount map[int]int
+	}{
+		{name: "all languages", want: 2, wantCount: map[int]int{1: 2, 2: 1}},
+		{name: "eng", lang3s: []string{"eng"}, want: 1, wantCount: map[int]int{1: 2, 2: 0}},
+	}
+	// a dry run does not write the tags, so only the existing tag is counted
+	dryRunCount := map[int]int{1: 1, 2: 0}
+
+	for driverName, db := range dbList() {
+		repo, err := gateway.NewTatoebaTagRepository(db, driverName)
+		require.NoError(t, err)
+
+		for _, tt := range tests {
+			t.Run(driverName+"/"+tt.name, func(t *testing.T) {
+				lang3s := make([]domain.Lang3, len(tt.lang3s))
+				for i, lang3 := range tt.lang3s {
+					lang3s[i] = newLang3(t, lang3)
+				}
+
+				for _, dryRun := range []bool{true, false} {
+					truncateTables(t, db)
+					addSentences(t, db, driverName, []testSentence{
+						{sentenceNumber: 1, lang3: "eng", text: "one"},
+						{sentenceNumber: 2, lang3: "jpn", text: "ni"},
+					}, nil)
+					_, err := repo.AddBatch(ctx, []service.TatoebaTagAddParameter{newTagAddParameter(t, 1, "a")}, nil)
+					require.NoError(t, err)
+
+					// the existing tag, the second occurrence in the batch and the tag of the sentence which does not exist are skipped
+					params := []service.TatoebaTagAddParameter{
+						newTagAddParameter(t, 1, "a"),
+						newTagAddParameter(t, 1, "b"),
+						newTagAddParameter(t, 1, "b"),
+						newTagAddParameter(t, 2, "c"),
+						newTagAddParameter(t, 9, "d"),
+					}
+					addBatch := repo.AddBatch
+					if dryRun {
+						addBatch = repo.CountNewTags
+					}
+					count, err := addBatch(ctx, params, lang3s)
+					require.NoError(t, err)
+					assert.Equal(t, tt.want, count)
+
+					for sentenceNumber, wantCount := range tt.wantCount {
+						if dryRun {
+							wantCount = dryRunCount[sentenceNumber]
+						}
+						count, err := repo.CountTagsBySentenceNumber(ctx, sentenceNumber, nil)
+						require.NoError(t, err)
+						assert.Equal(t, wantCount, count)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+)
+
+// tsvRowReader reads the rows of a Tatoeba dump, whose columns are separated by tabs without quotes.
+// It is shared by the iterators, which only parse the columns
+type tsvRowReader struct {
+	reader       *bufio.Reader
+	num          int
+	columnCounts []int
+}
+
+// newTSVRowReader returns the reader of rows with one of columnCounts columns. The input can be compressed with gzip or bzip2 and archived with tar
+func newTSVRowReader(reader io.Reader, columnCounts ...int) *tsvRowReader {
+	return &tsvRowReader{
+		reader:       bufio.NewReaderSize(newDecompressReader(reader), bufSize),
+		num:          1,
+		columnCounts: columnCounts,
+	}
+}
+
+// next reads the next row and passes its columns to parse. It returns RejectedRowError if the row cannot be split into columns or parse fails, and io.EOF at the end.
+// A row is rejected as malformed unless parse returns errTextTooLong
+func (r *tsvRowReader) next(parse func(columns []string) error) error {
+	line, tooLong, err := readLine(r.reader)
+	if err != nil {
+		return err
+	}
+
+	rowNumber := r.num
+	r.num++
+
+	if tooLong {
+		return &service.RejectedRowError{RowNumber: rowNumber, Code: service.RejectCodeMalformed, Reason: fmt.Sprintf("line is longer than %d bytes", maxLineLength)}
+	}
+
+	columns, err := r.split(line)
+	if err == nil {
+		err = parse(columns)
+	}
+	if err != nil {
+		code := service.RejectCodeMalformed
+		if errors.Is(err, errTextTooLong) {
+			code = service.RejectCodeTextTooLong
+		}
+		return &service.RejectedRowError{RowNumber: rowNumber, Code: code, Reason: err.Error()}
+	}
+
+	return nil
+}
+
+func (r *tsvRowReader) split(line string) ([]string, error) {
+	if !utf8.ValidString(line) {
+		return nil, errors.New("invalid UTF-8")
+	}
+
+	columns := strings.Split(line, "\t")
+	for _, columnCount := range r.columnCounts {
+		if len(columns) == columnCount {
+			return columns, nil
+		}
+	}
+
+	expected := make([]string, len(r.columnCounts))
+	for i, columnCount := range r.columnCounts {
+		expected[i] = fmt.Sprint(columnCount)
+	}
+	return nil, fmt.Errorf("unexpected number of columns. expected: %s, actual: %d", strings.Join(expected, " or "), len(columns))
+}
+
+// readLine returns the line without the line break. A line longer than maxLineLength is read to the end but returned truncated
+func readLine(reader *bufio.Reader) (string, bool, error) {
+	var line []byte
+	tooLong := false
+	for {
+		b, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return "", false, err
+		}
+
+		if len(line)+len(b) > maxLineLength {
+			tooLong = true
+		} else {
+			line = append(line, b...)
+		}
+
+		if !isPrefix {
+			return string(line), tooLong, nil
+		}
+	}
+}
@@ -0,0 +1,227 @@
+//go:generate mockery --output mock --name ImportJob
+//go:generate mockery --output mock --name ImportJobRepository
+//go:generate mockery --output mock --name ImportRejectedRow
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+var ErrImportJobNotFound = errors.New("importJob not found")
+var ErrImportJobAlreadyFinished = errors.New("importJob already finished")
+var ErrImportJobAlreadyRunning = errors.New("importJob already running")
+
+type ImportJobType string
+
+const (
+	ImportJobTypeSentence ImportJobType = "sentence"
+	ImportJobTypeLink     ImportJobType = "link"
+	ImportJobTypeTag      ImportJobType = "tag"
+	ImportJobTypeAudio    ImportJobType = "audio"
+	ImportJobTypeLicense  ImportJobType = "license"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusRunning   ImportJobStatus = "running"
+	ImportJobStatusSucceeded ImportJobStatus = "succeeded"
+	ImportJobStatusFailed    ImportJobStatus = "failed"
+	ImportJobStatusCancelled ImportJobStatus = "cancelled"
+)
+
+type ImportJob interface {
+	GetID() int
+	GetJobType() ImportJobType
+	GetOption() ImportOption
+	GetStatus() ImportJobStatus
+	// GetReadCount returns the number of rows read until the last committed batch. It is the checkpoint from which a resubmitted import resumes
+	GetReadCount() int
+	// GetImportCount returns the number of inserted rows
+	GetImportCount() int
+	// GetUpdateCount returns the number of updated rows. It is always 0 in ImportModeInsert
+	GetUpdateCount() int
+	// GetDeleteCount returns the number of removed rows. It is always 0 in ImportModeInsert
+	GetDeleteCount() int
+	// GetSkipCount returns the number of rows which are not inserted nor updated, including the rejected rows
+	GetSkipCount() int
+	// GetRejectCount returns the number of rejected rows, which are malformed or have texts longer than the limit. They are listed by FindImportJobRejectedRows
+	GetRejectCount() int
+	GetErrorMessage() string
+	// IsCancelRequested returns whether the cancellation is requested. The job stops at the next batch
+	IsCancelRequested() bool
+	GetStartedAt() time.Time
+	// GetFinishedAt returns nil while the job is running
+	GetFinishedAt() *time.Time
+	// GetResumedFromJobID returns the job from whose checkpoint the job resumed. nil means that the job started from the first row
+	GetResumedFromJobID() *int
+}
+
+type importJob struct {
+	ID               int           `validate:"required"`
+	JobType          ImportJobType `validate:"oneof=sentence link tag audio license"`
+	Option           ImportOption  `validate:"required"`
+	Status           ImportJobStatus
+	ReadCount        int
+	ImportCount      int
+	UpdateCount      int
+	DeleteCount      int
+	SkipCount        int
+	RejectCount      int
+	ErrorMessage     string
+	CancelRequested  bool
+	StartedAt        time.Time
+	FinishedAt       *time.Time
+	ResumedFromJobID *int
+}
+
+func NewImportJob(id int, jobType ImportJobType, option ImportOption, status ImportJobStatus, readCount, importCount, updateCount, deleteCount, skipCount, rejectCount int, errorMessage string, cancelRequested bool, startedAt time.Time, finishedAt *time.Time, resumedFromJobID *int) (ImportJob, error) {
+	m := &importJob{
+		ID:               id,
+		JobType:          jobType,
+		Option:           option,
+		Status:           status,
+		ReadCount:        readCount,
+		ImportCount:      importCount,
+		UpdateCount:      updateCount,
+		DeleteCount:      deleteCount,
+		SkipCount:        skipCount,
+		RejectCount:      rejectCount,
+		ErrorMessage:     errorMessage,
+		CancelRequested:  cancelRequested,
+		StartedAt:        startedAt,
+		FinishedAt:       finishedAt,
+		ResumedFromJobID: resumedFromJobID,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (m *importJob) GetID() int {
+	return m.ID
+}
+
+func (m *importJob) GetJobType() ImportJobType {
+	return m.JobType
+}
+
+func (m *importJob) GetOption() ImportOption {
+	return m.Option
+}
+
+func (m *importJob) GetStatus() ImportJobStatus {
+	return m.Status
+}
+
+func (m *importJob) GetReadCount() int {
+	return m.ReadCount
+}
+
+func (m *importJob) GetImportCount() int {
+	return m.ImportCount
+}
+
+func (m *importJob) GetUpdateCount() int {
+	return m.UpdateCount
+}
+
+func (m *importJob) GetDeleteCount() int {
+	return m.DeleteCount
+}
+
+func (m *importJob) GetSkipCount() int {
+	return m.SkipCount
+}
+
+func (m *importJob) GetRejectCount() int {
+	return m.RejectCount
+}
+
+func (m *importJob) GetErrorMessage() string {
+	return m.ErrorMessage
+}
+
+func (m *importJob) IsCancelRequested() bool {
+	return m.CancelRequested
+}
+
+func (m *importJob) GetStartedAt() time.Time {
+	return m.StartedAt
+}
+
+func (m *importJob) GetFinishedAt() *time.Time {
+	return m.FinishedAt
+}
+
+func (m *importJob) GetResumedFromJobID() *int {
+	return m.ResumedFromJobID
+}
+
+// ImportRejectedRow is a row which the import job rejected
+type ImportRejectedRow interface {
+	GetRowNumber() int
+	GetCode() RejectCode
+	GetReason() string
+}
+
+type importRejectedRow struct {
+	RowNumber int        `validate:"required"`
+	Code      RejectCode `validate:"oneof=malformed text_too_long"`
+	Reason    string
+}
+
+func NewImportRejectedRow(rowNumber int, code RejectCode, reason string) (ImportRejectedRow, error) {
+	m := &importRejectedRow{
+		RowNumber: rowNumber,
+		Code:      code,
+		Reason:    reason,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (m *importRejectedRow) GetRowNumber() int {
+	return m.RowNumber
+}
+
+func (m *importRejectedRow) GetCode() RejectCode {
+	return m.Code
+}
+
+func (m *importRejectedRow) GetReason() string {
+	return m.Reason
+}
+
+type ImportJobRepository interface {
+	// AddImportJob adds the running job with its first heartbeat and returns its ID. resumedFromJobID is nil if the job starts from the first row
+	AddImportJob(ctx context.Context, jobType ImportJobType, option ImportOption, resumedFromJobID *int) (int, error)
+
+	FindImportJob(ctx context.Context, id int) (ImportJob, error)
+
+	// FindLatestImportJobByFileChecksum returns the latest job which imported the same file
+	FindLatestImportJobByFileChecksum(ctx context.Context, jobType ImportJobType, fileChecksum string) (ImportJob, error)
+
+	UpdateImportJobProgress(ctx context.Context, id, readCount, importCount, updateCount, deleteCount, skipCount, rejectCount int) error
+
+	AddImportJobRejectedRows(ctx context.Context, id int, rows []ImportRejectedRow) error
+
+	// FindImportJobRejectedRows returns the rejected rows of the job in order of row number
+	FindImportJobRejectedRows(ctx context.Context, id int) ([]ImportRejectedRow, error)
+
+	// FinishImportJob changes the status of the running job
+	FinishImportJob(ctx context.Context, id int, status ImportJobStatus, errorMessage string) error
+
+	// UpdateImportJobHeartbeat records that the process running the job is alive. A running job keeps its lease while the heartbeats continue
+	UpdateImportJobHeartbeat(ctx context.Context, id int) error
+
+	// FailExpiredImportJobs fails the running jobs whose last heartbeat is before expiredAt and returns the number of the jobs.
+	// Their processes stopped, so the jobs never finish by themselves
+	FailExpiredImportJobs(ctx context.Context, expiredAt time.Time, errorMessage string) (int, error)
+
+	// RequestImportJobCancel marks the running job to be cancelled. It returns ErrImportJobAlreadyFinished if the job is not running
+	RequestImportJobCancel(ctx context.Context, id int) error
+}
@@ -0,0 +1,88 @@
+//go:generate mockery --output mock --name ImportOption
+package service
+
+import (
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+// ImportMode is how an import treats the existing rows.
+// ImportModeInsert only adds new rows. ImportModeSync also updates changed rows and removes rows absent from the file
+type ImportMode string
+
+const (
+	ImportModeInsert ImportMode = "insert"
+	ImportModeSync   ImportMode = "sync"
+)
+
+type ImportOption interface {
+	// GetFileChecksum returns the SHA-256 checksum of the imported file. An empty checksum never resumes a job
+	GetFileChecksum() string
+	GetMode() ImportMode
+	// GetLang3s returns the languages to import. Sentences of other languages and links to them are skipped. Empty means all languages
+	GetLang3s() []domain.Lang3
+	// ContainsLang3 returns whether sentences of the language are imported
+	ContainsLang3(lang3 domain.Lang3) bool
+	// IsDryRun returns whether the import only validates the file and counts the rows against the database without writing them
+	IsDryRun() bool
+	// Equals returns whether the options import the same rows, so that a job can resume from the checkpoint of another
+	Equals(other ImportOption) bool
+}
+
+type importOption struct {
+	FileChecksum string
+	Mode         ImportMode `validate:"oneof=insert sync"`
+	Lang3s       []domain.Lang3
+	DryRun       bool
+}
+
+func NewImportOption(fileChecksum string, mode ImportMode, lang3s []domain.Lang3, dryRun bool) (ImportOption, error) {
+	m := &importOption{
+		FileChecksum: fileChecksum,
+		Mode:         mode,
+		Lang3s:       lang3s,
+		DryRun:       dryRun,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (m *importOption) GetFileChecksum() string {
+	return m.FileChecksum
+}
+
+func (m *importOption) GetMode() ImportMode {
+	return m.Mode
+}
+
+func (m *importOption) GetLang3s() []domain.Lang3 {
+	return m.Lang3s
+}
+
+func (m *importOption) ContainsLang3(lang3 domain.Lang3) bool {
+	if len(m.Lang3s) == 0 {
+		return true
+	}
+	for _, l := range m.Lang3s {
+		if l.String() == lang3.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *importOption) IsDryRun() bool {
+	return m.DryRun
+}
+
+func (m *importOption) Equals(other ImportOption) bool {
+	if m.Mode != other.GetMode() || m.DryRun != other.IsDryRun() || len(m.Lang3s) != len(other.GetLang3s()) {
+		return false
+	}
+	for _, lang3 := range other.GetLang3s() {
+		if !m.ContainsLang3(lang3) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+
+	time "time"
+)
+
+// ImportJob is an autogenerated mock type for the ImportJob type
+type ImportJob struct {
+	mock.Mock
+}
+
+// GetDeleteCount provides a mock function with given fields:
+func (_m *ImportJob) GetDeleteCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetErrorMessage provides a mock function with given fields:
+func (_m *ImportJob) GetErrorMessage() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetFinishedAt provides a mock function with given fields:
+func (_m *ImportJob) GetFinishedAt() *time.Time {
+	ret := _m.Called()
+
+	var r0 *time.Time
+	if rf, ok := ret.Get(0).(func() *time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	return r0
+}
+
+// GetID provides a mock function with given fields:
+func (_m *ImportJob) GetID() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetImportCount provides a mock function with given fields:
+func (_m *ImportJob) GetImportCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetJobType provides a mock function with given fields:
+func (_m *ImportJob) GetJobType() service.ImportJobType {
+	ret := _m.Called()
+
+	var r0 service.ImportJobType
+	if rf, ok := ret.Get(0).(func() service.ImportJobType); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.ImportJobType)
+	}
+
+	return r0
+}
+
+// GetOption provides a mock function with given fields:
+func (_m *ImportJob) GetOption() service.ImportOption {
+	ret := _m.Called()
+
+	var r0 service.ImportOption
+	if rf, ok := ret.Get(0).(func() service.ImportOption); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.ImportOption)
+		}
+	}
+
+	return r0
+}
+
+// GetReadCount provides a mock function with given fields:
+func (_m *ImportJob) GetReadCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetRejectCount provides a mock function with given fields:
+func (_m *ImportJob) GetRejectCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetResumedFromJobID provides a mock function with given fields:
+func (_m *ImportJob) GetResumedFromJobID() *int {
+	ret := _m.Called()
+
+	var r0 *int
+	if rf, ok := ret.Get(0).(func() *int); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	return r0
+}
+
+// GetSkipCount provides a mock function with given fields:
+func (_m *ImportJob) GetSkipCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetStartedAt provides a mock function with given fields:
+func (_m *ImportJob) GetStartedAt() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// GetStatus provides a mock function with given fields:
+func (_m *ImportJob) GetStatus() service.ImportJobStatus {
+	ret := _m.Called()
+
+	var r0 service.ImportJobStatus
+	if rf, ok := ret.Get(0).(func() service.ImportJobStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.ImportJobStatus)
+	}
+
+	return r0
+}
+
+// GetUpdateCount provides a mock function with given fields:
+func (_m *ImportJob) GetUpdateCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// IsCancelRequested provides a mock function with given fields:
+func (_m *ImportJob) IsCancelRequested() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewImportJob creates a new instance of ImportJob. It also registers a cleanup function to assert the mocks expectations.
+func NewImportJob(t testing.TB) *ImportJob {
+	mock := &ImportJob{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
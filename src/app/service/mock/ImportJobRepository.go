@@ -0,0 +1,209 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+
+	time "time"
+)
+
+// ImportJobRepository is an autogenerated mock type for the ImportJobRepository type
+type ImportJobRepository struct {
+	mock.Mock
+}
+
+// AddImportJob provides a mock function with given fields: ctx, jobType, option, resumedFromJobID
+func (_m *ImportJobRepository) AddImportJob(ctx context.Context, jobType service.ImportJobType, option service.ImportOption, resumedFromJobID *int) (int, error) {
+	ret := _m.Called(ctx, jobType, option, resumedFromJobID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, service.ImportJobType, service.ImportOption, *int) int); ok {
+		r0 = rf(ctx, jobType, option, resumedFromJobID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.ImportJobType, service.ImportOption, *int) error); ok {
+		r1 = rf(ctx, jobType, option, resumedFromJobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddImportJobRejectedRows provides a mock function with given fields: ctx, id, rows
+func (_m *ImportJobRepository) AddImportJobRejectedRows(ctx context.Context, id int, rows []service.ImportRejectedRow) error {
+	ret := _m.Called(ctx, id, rows)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []service.ImportRejectedRow) error); ok {
+		r0 = rf(ctx, id, rows)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailExpiredImportJobs provides a mock function with given fields: ctx, expiredAt, errorMessage
+func (_m *ImportJobRepository) FailExpiredImportJobs(ctx context.Context, expiredAt time.Time, errorMessage string) (int, error) {
+	ret := _m.Called(ctx, expiredAt, errorMessage)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, string) int); ok {
+		r0 = rf(ctx, expiredAt, errorMessage)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, string) error); ok {
+		r1 = rf(ctx, expiredAt, errorMessage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindImportJob provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepository) FindImportJob(ctx context.Context, id int) (service.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 service.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, int) service.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.ImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindImportJobRejectedRows provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepository) FindImportJobRejectedRows(ctx context.Context, id int) ([]service.ImportRejectedRow, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []service.ImportRejectedRow
+	if rf, ok := ret.Get(0).(func(context.Context, int) []service.ImportRejectedRow); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ImportRejectedRow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatestImportJobByFileChecksum provides a mock function with given fields: ctx, jobType, fileChecksum
+func (_m *ImportJobRepository) FindLatestImportJobByFileChecksum(ctx context.Context, jobType service.ImportJobType, fileChecksum string) (service.ImportJob, error) {
+	ret := _m.Called(ctx, jobType, fileChecksum)
+
+	var r0 service.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, service.ImportJobType, string) service.ImportJob); ok {
+		r0 = rf(ctx, jobType, fileChecksum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.ImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.ImportJobType, string) error); ok {
+		r1 = rf(ctx, jobType, fileChecksum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishImportJob provides a mock function with given fields: ctx, id, status, errorMessage
+func (_m *ImportJobRepository) FinishImportJob(ctx context.Context, id int, status service.ImportJobStatus, errorMessage string) error {
+	ret := _m.Called(ctx, id, status, errorMessage)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, service.ImportJobStatus, string) error); ok {
+		r0 = rf(ctx, id, status, errorMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestImportJobCancel provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepository) RequestImportJobCancel(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateImportJobHeartbeat provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepository) UpdateImportJobHeartbeat(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateImportJobProgress provides a mock function with given fields: ctx, id, readCount, importCount, updateCount, deleteCount, skipCount, rejectCount
+func (_m *ImportJobRepository) UpdateImportJobProgress(ctx context.Context, id int, readCount int, importCount int, updateCount int, deleteCount int, skipCount int, rejectCount int) error {
+	ret := _m.Called(ctx, id, readCount, importCount, updateCount, deleteCount, skipCount, rejectCount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int, int, int, int) error); ok {
+		r0 = rf(ctx, id, readCount, importCount, updateCount, deleteCount, skipCount, rejectCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewImportJobRepository creates a new instance of ImportJobRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewImportJobRepository(t testing.TB) *ImportJobRepository {
+	mock := &ImportJobRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// ImportOption is an autogenerated mock type for the ImportOption type
+type ImportOption struct {
+	mock.Mock
+}
+
+// ContainsLang3 provides a mock function with given fields: lang3
+func (_m *ImportOption) ContainsLang3(lang3 domain.Lang3) bool {
+	ret := _m.Called(lang3)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(domain.Lang3) bool); ok {
+		r0 = rf(lang3)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Equals provides a mock function with given fields: other
+func (_m *ImportOption) Equals(other service.ImportOption) bool {
+	ret := _m.Called(other)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(service.ImportOption) bool); ok {
+		r0 = rf(other)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// GetFileChecksum provides a mock function with given fields:
+func (_m *ImportOption) GetFileChecksum() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetLang3s provides a mock function with given fields:
+func (_m *ImportOption) GetLang3s() []domain.Lang3 {
+	ret := _m.Called()
+
+	var r0 []domain.Lang3
+	if rf, ok := ret.Get(0).(func() []domain.Lang3); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Lang3)
+		}
+	}
+
+	return r0
+}
+
+// GetMode provides a mock function with given fields:
+func (_m *ImportOption) GetMode() service.ImportMode {
+	ret := _m.Called()
+
+	var r0 service.ImportMode
+	if rf, ok := ret.Get(0).(func() service.ImportMode); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.ImportMode)
+	}
+
+	return r0
+}
+
+// IsDryRun provides a mock function with given fields:
+func (_m *ImportOption) IsDryRun() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewImportOption creates a new instance of ImportOption. It also registers a cleanup function to assert the mocks expectations.
+func NewImportOption(t testing.TB) *ImportOption {
+	mock := &ImportOption{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
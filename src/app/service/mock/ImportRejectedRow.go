@@ -0,0 +1,66 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// ImportRejectedRow is an autogenerated mock type for the ImportRejectedRow type
+type ImportRejectedRow struct {
+	mock.Mock
+}
+
+// GetCode provides a mock function with given fields:
+func (_m *ImportRejectedRow) GetCode() service.RejectCode {
+	ret := _m.Called()
+
+	var r0 service.RejectCode
+	if rf, ok := ret.Get(0).(func() service.RejectCode); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.RejectCode)
+	}
+
+	return r0
+}
+
+// GetReason provides a mock function with given fields:
+func (_m *ImportRejectedRow) GetReason() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetRowNumber provides a mock function with given fields:
+func (_m *ImportRejectedRow) GetRowNumber() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// NewImportRejectedRow creates a new instance of ImportRejectedRow. It also registers a cleanup function to assert the mocks expectations.
+func NewImportRejectedRow(t testing.TB) *ImportRejectedRow {
+	mock := &ImportRejectedRow{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
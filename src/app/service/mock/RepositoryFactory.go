@@ -0,0 +1,141 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// RepositoryFactory is an autogenerated mock type for the RepositoryFactory type
+type RepositoryFactory struct {
+	mock.Mock
+}
+
+// NewImportJobRepository provides a mock function with given fields: ctx
+func (_m *RepositoryFactory) NewImportJobRepository(ctx context.Context) (service.ImportJobRepository, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.ImportJobRepository
+	if rf, ok := ret.Get(0).(func(context.Context) service.ImportJobRepository); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.ImportJobRepository)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaAudioRepository provides a mock function with given fields: ctx
+func (_m *RepositoryFactory) NewTatoebaAudioRepository(ctx context.Context) (service.TatoebaAudioRepository, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.TatoebaAudioRepository
+	if rf, ok := ret.Get(0).(func(context.Context) service.TatoebaAudioRepository); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaAudioRepository)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaLinkRepository provides a mock function with given fields: ctx
+func (_m *RepositoryFactory) NewTatoebaLinkRepository(ctx context.Context) (service.TatoebaLinkRepository, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.TatoebaLinkRepository
+	if rf, ok := ret.Get(0).(func(context.Context) service.TatoebaLinkRepository); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaLinkRepository)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaSentenceRepository provides a mock function with given fields: ctx
+func (_m *RepositoryFactory) NewTatoebaSentenceRepository(ctx context.Context) (service.TatoebaSentenceRepository, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.TatoebaSentenceRepository
+	if rf, ok := ret.Get(0).(func(context.Context) service.TatoebaSentenceRepository); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentenceRepository)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaTagRepository provides a mock function with given fields: ctx
+func (_m *RepositoryFactory) NewTatoebaTagRepository(ctx context.Context) (service.TatoebaTagRepository, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.TatoebaTagRepository
+	if rf, ok := ret.Get(0).(func(context.Context) service.TatoebaTagRepository); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaTagRepository)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRepositoryFactory creates a new instance of RepositoryFactory. It also registers a cleanup function to assert the mocks expectations.
+func NewRepositoryFactory(t testing.TB) *RepositoryFactory {
+	mock := &RepositoryFactory{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
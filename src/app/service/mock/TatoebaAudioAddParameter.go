@@ -0,0 +1,93 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaAudioAddParameter is an autogenerated mock type for the TatoebaAudioAddParameter type
+type TatoebaAudioAddParameter struct {
+	mock.Mock
+}
+
+// GetAttributionURL provides a mock function with given fields:
+func (_m *TatoebaAudioAddParameter) GetAttributionURL() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetAudioID provides a mock function with given fields:
+func (_m *TatoebaAudioAddParameter) GetAudioID() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetAuthor provides a mock function with given fields:
+func (_m *TatoebaAudioAddParameter) GetAuthor() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetLicense provides a mock function with given fields:
+func (_m *TatoebaAudioAddParameter) GetLicense() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetSentenceNumber provides a mock function with given fields:
+func (_m *TatoebaAudioAddParameter) GetSentenceNumber() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// NewTatoebaAudioAddParameter creates a new instance of TatoebaAudioAddParameter. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaAudioAddParameter(t testing.TB) *TatoebaAudioAddParameter {
+	mock := &TatoebaAudioAddParameter{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,49 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaAudioAddParameterIterator is an autogenerated mock type for the TatoebaAudioAddParameterIterator type
+type TatoebaAudioAddParameterIterator struct {
+	mock.Mock
+}
+
+// Next provides a mock function with given fields: ctx
+func (_m *TatoebaAudioAddParameterIterator) Next(ctx context.Context) (service.TatoebaAudioAddParameter, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.TatoebaAudioAddParameter
+	if rf, ok := ret.Get(0).(func(context.Context) service.TatoebaAudioAddParameter); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaAudioAddParameter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaAudioAddParameterIterator creates a new instance of TatoebaAudioAddParameterIterator. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaAudioAddParameterIterator(t testing.TB) *TatoebaAudioAddParameterIterator {
+	mock := &TatoebaAudioAddParameterIterator{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,178 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaAudioRepository is an autogenerated mock type for the TatoebaAudioRepository type
+type TatoebaAudioRepository struct {
+	mock.Mock
+}
+
+// AddBatch provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaAudioRepository) AddBatch(ctx context.Context, params []service.TatoebaAudioAddParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaAudioAddParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaAudioAddParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountNewAudios provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaAudioRepository) CountNewAudios(ctx context.Context, params []service.TatoebaAudioAddParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaAudioAddParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaAudioAddParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTatoebaAudios provides a mock function with given fields: ctx, lang3s, afterAudioID, limit
+func (_m *TatoebaAudioRepository) FindTatoebaAudios(ctx context.Context, lang3s []domain.Lang3, afterAudioID int, limit int) ([]service.TatoebaAudio, error) {
+	ret := _m.Called(ctx, lang3s, afterAudioID, limit)
+
+	var r0 []service.TatoebaAudio
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Lang3, int, int) []service.TatoebaAudio); ok {
+		r0 = rf(ctx, lang3s, afterAudioID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.TatoebaAudio)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.Lang3, int, int) error); ok {
+		r1 = rf(ctx, lang3s, afterAudioID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTatoebaAudiosBySentenceNumbers provides a mock function with given fields: ctx, sentenceNumbers
+func (_m *TatoebaAudioRepository) FindTatoebaAudiosBySentenceNumbers(ctx context.Context, sentenceNumbers []int) (map[int][]service.TatoebaAudio, error) {
+	ret := _m.Called(ctx, sentenceNumbers)
+
+	var r0 map[int][]service.TatoebaAudio
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int][]service.TatoebaAudio); ok {
+		r0 = rf(ctx, sentenceNumbers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]service.TatoebaAudio)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, sentenceNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreviewSync provides a mock function with given fields: ctx, param
+func (_m *TatoebaAudioRepository) PreviewSync(ctx context.Context, param service.TatoebaAudioAddParameter) (service.TatoebaAudioSyncResult, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 service.TatoebaAudioSyncResult
+	if rf, ok := ret.Get(0).(func(context.Context, service.TatoebaAudioAddParameter) service.TatoebaAudioSyncResult); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(service.TatoebaAudioSyncResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.TatoebaAudioAddParameter) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTatoebaAudios provides a mock function with given fields: ctx, audioIDs
+func (_m *TatoebaAudioRepository) RemoveTatoebaAudios(ctx context.Context, audioIDs []int) (int, error) {
+	ret := _m.Called(ctx, audioIDs)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) int); ok {
+		r0 = rf(ctx, audioIDs)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, audioIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Sync provides a mock function with given fields: ctx, param
+func (_m *TatoebaAudioRepository) Sync(ctx context.Context, param service.TatoebaAudioAddParameter) (service.TatoebaAudioSyncResult, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 service.TatoebaAudioSyncResult
+	if rf, ok := ret.Get(0).(func(context.Context, service.TatoebaAudioAddParameter) service.TatoebaAudioSyncResult); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(service.TatoebaAudioSyncResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.TatoebaAudioAddParameter) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaAudioRepository creates a new instance of TatoebaAudioRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaAudioRepository(t testing.TB) *TatoebaAudioRepository {
+	mock := &TatoebaAudioRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	domain "github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	mock "github.com/stretchr/testify/mock"
 
@@ -30,6 +31,146 @@ func (_m *TatoebaLinkRepository) Add(ctx context.Context, param service.TatoebaL
 	return r0
 }
 
+// AddBatch provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaLinkRepository) AddBatch(ctx context.Context, params []service.TatoebaLinkAddParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaLinkAddParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaLinkAddParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountLinksByFrom provides a mock function with given fields: ctx, from, keptTos
+func (_m *TatoebaLinkRepository) CountLinksByFrom(ctx context.Context, from int, keptTos []int) (int, error) {
+	ret := _m.Called(ctx, from, keptTos)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) int); ok {
+		r0 = rf(ctx, from, keptTos)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, from, keptTos)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountLinksByFromRange provides a mock function with given fields: ctx, afterFrom, beforeFrom
+func (_m *TatoebaLinkRepository) CountLinksByFromRange(ctx context.Context, afterFrom int, beforeFrom int) (int, error) {
+	ret := _m.Called(ctx, afterFrom, beforeFrom)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, afterFrom, beforeFrom)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, afterFrom, beforeFrom)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountNewLinks provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaLinkRepository) CountNewLinks(ctx context.Context, params []service.TatoebaLinkAddParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaLinkAddParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaLinkAddParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshSentencePairCounts provides a mock function with given fields: ctx
+func (_m *TatoebaLinkRepository) RefreshSentencePairCounts(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveLinksByFrom provides a mock function with given fields: ctx, from, keptTos
+func (_m *TatoebaLinkRepository) RemoveLinksByFrom(ctx context.Context, from int, keptTos []int) (int, error) {
+	ret := _m.Called(ctx, from, keptTos)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) int); ok {
+		r0 = rf(ctx, from, keptTos)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []int) error); ok {
+		r1 = rf(ctx, from, keptTos)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveLinksByFromRange provides a mock function with given fields: ctx, afterFrom, beforeFrom
+func (_m *TatoebaLinkRepository) RemoveLinksByFromRange(ctx context.Context, afterFrom int, beforeFrom int) (int, error) {
+	ret := _m.Called(ctx, afterFrom, beforeFrom)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, afterFrom, beforeFrom)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, afterFrom, beforeFrom)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTatoebaLinkRepository creates a new instance of TatoebaLinkRepository. It also registers a cleanup function to assert the mocks expectations.
 func NewTatoebaLinkRepository(t testing.TB) *TatoebaLinkRepository {
 	mock := &TatoebaLinkRepository{}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	domain "github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
@@ -16,6 +17,22 @@ type TatoebaSentence struct {
 	mock.Mock
 }
 
+// GetAudios provides a mock function with given fields:
+func (_m *TatoebaSentence) GetAudios() []service.TatoebaAudio {
+	ret := _m.Called()
+
+	var r0 []service.TatoebaAudio
+	if rf, ok := ret.Get(0).(func() []service.TatoebaAudio); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.TatoebaAudio)
+		}
+	}
+
+	return r0
+}
+
 // GetAuthor provides a mock function with given fields:
 func (_m *TatoebaSentence) GetAuthor() string {
 	ret := _m.Called()
@@ -30,6 +47,20 @@ func (_m *TatoebaSentence) GetAuthor() string {
 	return r0
 }
 
+// GetDifficulty provides a mock function with given fields:
+func (_m *TatoebaSentence) GetDifficulty() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // GetLang3 provides a mock function with given fields:
 func (_m *TatoebaSentence) GetLang3() domain.Lang3 {
 	ret := _m.Called()
@@ -46,6 +77,20 @@ func (_m *TatoebaSentence) GetLang3() domain.Lang3 {
 	return r0
 }
 
+// GetLicense provides a mock function with given fields:
+func (_m *TatoebaSentence) GetLicense() service.TatoebaSentenceLicense {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentenceLicense
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentenceLicense); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.TatoebaSentenceLicense)
+	}
+
+	return r0
+}
+
 // GetSentenceNumber provides a mock function with given fields:
 func (_m *TatoebaSentence) GetSentenceNumber() int {
 	ret := _m.Called()
@@ -88,6 +133,20 @@ func (_m *TatoebaSentence) GetUpdatedAt() time.Time {
 	return r0
 }
 
+// GetWordCount provides a mock function with given fields:
+func (_m *TatoebaSentence) GetWordCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // NewTatoebaSentence creates a new instance of TatoebaSentence. It also registers a cleanup function to assert the mocks expectations.
 func NewTatoebaSentence(t testing.TB) *TatoebaSentence {
 	mock := &TatoebaSentence{}
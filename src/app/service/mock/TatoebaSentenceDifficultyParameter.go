@@ -0,0 +1,65 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaSentenceDifficultyParameter is an autogenerated mock type for the TatoebaSentenceDifficultyParameter type
+type TatoebaSentenceDifficultyParameter struct {
+	mock.Mock
+}
+
+// GetDifficulty provides a mock function with given fields:
+func (_m *TatoebaSentenceDifficultyParameter) GetDifficulty() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetSentenceNumber provides a mock function with given fields:
+func (_m *TatoebaSentenceDifficultyParameter) GetSentenceNumber() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetWordCount provides a mock function with given fields:
+func (_m *TatoebaSentenceDifficultyParameter) GetWordCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// NewTatoebaSentenceDifficultyParameter creates a new instance of TatoebaSentenceDifficultyParameter. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaSentenceDifficultyParameter(t testing.TB) *TatoebaSentenceDifficultyParameter {
+	mock := &TatoebaSentenceDifficultyParameter{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
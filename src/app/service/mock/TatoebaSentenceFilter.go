@@ -0,0 +1,234 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+
+	time "time"
+)
+
+// TatoebaSentenceFilter is an autogenerated mock type for the TatoebaSentenceFilter type
+type TatoebaSentenceFilter struct {
+	mock.Mock
+}
+
+// GetExcludeAuthors provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetExcludeAuthors() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetExcludeTags provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetExcludeTags() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetIncludeAuthors provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetIncludeAuthors() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetIncludeTags provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetIncludeTags() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetLicense provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetLicense() service.TatoebaSentenceLicense {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentenceLicense
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentenceLicense); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.TatoebaSentenceLicense)
+	}
+
+	return r0
+}
+
+// GetMaxDifficulty provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetMaxDifficulty() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetMaxLength provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetMaxLength() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetMaxWordCount provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetMaxWordCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetMinDifficulty provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetMinDifficulty() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetMinLength provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetMinLength() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetMinWordCount provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetMinWordCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetUpdatedAfter provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetUpdatedAfter() *time.Time {
+	ret := _m.Called()
+
+	var r0 *time.Time
+	if rf, ok := ret.Get(0).(func() *time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	return r0
+}
+
+// GetUpdatedBefore provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) GetUpdatedBefore() *time.Time {
+	ret := _m.Called()
+
+	var r0 *time.Time
+	if rf, ok := ret.Get(0).(func() *time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	return r0
+}
+
+// IsWithAudioOnly provides a mock function with given fields:
+func (_m *TatoebaSentenceFilter) IsWithAudioOnly() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewTatoebaSentenceFilter creates a new instance of TatoebaSentenceFilter. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaSentenceFilter(t testing.TB) *TatoebaSentenceFilter {
+	mock := &TatoebaSentenceFilter{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaSentenceKeywordCondition is an autogenerated mock type for the TatoebaSentenceKeywordCondition type
+type TatoebaSentenceKeywordCondition struct {
+	mock.Mock
+}
+
+// GetDstKeyword provides a mock function with given fields:
+func (_m *TatoebaSentenceKeywordCondition) GetDstKeyword() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetKeyword provides a mock function with given fields:
+func (_m *TatoebaSentenceKeywordCondition) GetKeyword() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetMatchMode provides a mock function with given fields:
+func (_m *TatoebaSentenceKeywordCondition) GetMatchMode() service.MatchMode {
+	ret := _m.Called()
+
+	var r0 service.MatchMode
+	if rf, ok := ret.Get(0).(func() service.MatchMode); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.MatchMode)
+	}
+
+	return r0
+}
+
+// GetTarget provides a mock function with given fields:
+func (_m *TatoebaSentenceKeywordCondition) GetTarget() service.KeywordTarget {
+	ret := _m.Called()
+
+	var r0 service.KeywordTarget
+	if rf, ok := ret.Get(0).(func() service.KeywordTarget); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.KeywordTarget)
+	}
+
+	return r0
+}
+
+// NewTatoebaSentenceKeywordCondition creates a new instance of TatoebaSentenceKeywordCondition. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaSentenceKeywordCondition(t testing.TB) *TatoebaSentenceKeywordCondition {
+	mock := &TatoebaSentenceKeywordCondition{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
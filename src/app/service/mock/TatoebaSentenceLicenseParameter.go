@@ -0,0 +1,52 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaSentenceLicenseParameter is an autogenerated mock type for the TatoebaSentenceLicenseParameter type
+type TatoebaSentenceLicenseParameter struct {
+	mock.Mock
+}
+
+// GetLicense provides a mock function with given fields:
+func (_m *TatoebaSentenceLicenseParameter) GetLicense() service.TatoebaSentenceLicense {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentenceLicense
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentenceLicense); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.TatoebaSentenceLicense)
+	}
+
+	return r0
+}
+
+// GetSentenceNumber provides a mock function with given fields:
+func (_m *TatoebaSentenceLicenseParameter) GetSentenceNumber() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// NewTatoebaSentenceLicenseParameter creates a new instance of TatoebaSentenceLicenseParameter. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaSentenceLicenseParameter(t testing.TB) *TatoebaSentenceLicenseParameter {
+	mock := &TatoebaSentenceLicenseParameter{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
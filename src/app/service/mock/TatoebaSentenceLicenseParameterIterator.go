@@ -0,0 +1,49 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaSentenceLicenseParameterIterator is an autogenerated mock type for the TatoebaSentenceLicenseParameterIterator type
+type TatoebaSentenceLicenseParameterIterator struct {
+	mock.Mock
+}
+
+// Next provides a mock function with given fields: ctx
+func (_m *TatoebaSentenceLicenseParameterIterator) Next(ctx context.Context) (service.TatoebaSentenceLicenseParameter, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.TatoebaSentenceLicenseParameter
+	if rf, ok := ret.Get(0).(func(context.Context) service.TatoebaSentenceLicenseParameter); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentenceLicenseParameter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaSentenceLicenseParameterIterator creates a new instance of TatoebaSentenceLicenseParameterIterator. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaSentenceLicenseParameterIterator(t testing.TB) *TatoebaSentenceLicenseParameterIterator {
+	mock := &TatoebaSentenceLicenseParameterIterator{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,10 +3,10 @@
 package mocks
 
 import (
-	testing "testing"
-
 	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
 )
 
 // TatoebaSentencePair is an autogenerated mock type for the TatoebaSentencePair type
@@ -30,6 +30,22 @@ func (_m *TatoebaSentencePair) GetDst() service.TatoebaSentence {
 	return r0
 }
 
+// GetPivot provides a mock function with given fields:
+func (_m *TatoebaSentencePair) GetPivot() service.TatoebaSentence {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentence
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentence); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentence)
+		}
+	}
+
+	return r0
+}
+
 // GetSrc provides a mock function with given fields:
 func (_m *TatoebaSentencePair) GetSrc() service.TatoebaSentence {
 	ret := _m.Called()
@@ -46,6 +62,20 @@ func (_m *TatoebaSentencePair) GetSrc() service.TatoebaSentence {
 	return r0
 }
 
+// IsDirect provides a mock function with given fields:
+func (_m *TatoebaSentencePair) IsDirect() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // NewTatoebaSentencePair creates a new instance of TatoebaSentencePair. It also registers a cleanup function to assert the mocks expectations.
 func NewTatoebaSentencePair(t testing.TB) *TatoebaSentencePair {
 	mock := &TatoebaSentencePair{}
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaSentencePairCursor is an autogenerated mock type for the TatoebaSentencePairCursor type
+type TatoebaSentencePairCursor struct {
+	mock.Mock
+}
+
+// GetDstSentenceNumber provides a mock function with given fields:
+func (_m *TatoebaSentencePairCursor) GetDstSentenceNumber() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetSrcSentenceNumber provides a mock function with given fields:
+func (_m *TatoebaSentencePairCursor) GetSrcSentenceNumber() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// String provides a mock function with given fields:
+func (_m *TatoebaSentencePairCursor) String() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NewTatoebaSentencePairCursor creates a new instance of TatoebaSentencePairCursor. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaSentencePairCursor(t testing.TB) *TatoebaSentencePairCursor {
+	mock := &TatoebaSentencePairCursor{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,10 +3,10 @@
 package mocks
 
 import (
-	testing "testing"
-
 	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
 )
 
 // TatoebaSentencePairSearchResult is an autogenerated mock type for the TatoebaSentencePairSearchResult type
@@ -14,6 +14,22 @@ type TatoebaSentencePairSearchResult struct {
 	mock.Mock
 }
 
+// GetNextCursor provides a mock function with given fields:
+func (_m *TatoebaSentencePairSearchResult) GetNextCursor() service.TatoebaSentencePairCursor {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentencePairCursor
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentencePairCursor); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentencePairCursor)
+		}
+	}
+
+	return r0
+}
+
 // GetResults provides a mock function with given fields:
 func (_m *TatoebaSentencePairSearchResult) GetResults() []service.TatoebaSentencePair {
 	ret := _m.Called()
@@ -44,6 +60,20 @@ func (_m *TatoebaSentencePairSearchResult) GetTotalCount() int {
 	return r0
 }
 
+// IsTotalCountCapped provides a mock function with given fields:
+func (_m *TatoebaSentencePairSearchResult) IsTotalCountCapped() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // NewTatoebaSentencePairSearchResult creates a new instance of TatoebaSentencePairSearchResult. It also registers a cleanup function to assert the mocks expectations.
 func NewTatoebaSentencePairSearchResult(t testing.TB) *TatoebaSentencePairSearchResult {
 	mock := &TatoebaSentencePairSearchResult{}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	domain "github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	mock "github.com/stretchr/testify/mock"
 
@@ -30,6 +31,27 @@ func (_m *TatoebaSentenceRepository) Add(ctx context.Context, param service.Tato
 	return r0
 }
 
+// AddBatch provides a mock function with given fields: ctx, params
+func (_m *TatoebaSentenceRepository) AddBatch(ctx context.Context, params []service.TatoebaSentenceAddParameter) (int, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaSentenceAddParameter) int); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaSentenceAddParameter) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ContainsSentenceBySentenceNumber provides a mock function with given fields: ctx, sentenceNumber
 func (_m *TatoebaSentenceRepository) ContainsSentenceBySentenceNumber(ctx context.Context, sentenceNumber int) (bool, error) {
 	ret := _m.Called(ctx, sentenceNumber)
@@ -51,6 +73,73 @@ func (_m *TatoebaSentenceRepository) ContainsSentenceBySentenceNumber(ctx contex
 	return r0, r1
 }
 
+// ContainsSentencesBySentenceNumbers provides a mock function with given fields: ctx, sentenceNumbers, lang3s
+func (_m *TatoebaSentenceRepository) ContainsSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int, lang3s []domain.Lang3) (map[int]bool, error) {
+	ret := _m.Called(ctx, sentenceNumbers, lang3s)
+
+	var r0 map[int]bool
+	if rf, ok := ret.Get(0).(func(context.Context, []int, []domain.Lang3) map[int]bool); ok {
+		r0 = rf(ctx, sentenceNumbers, lang3s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int, []domain.Lang3) error); ok {
+		r1 = rf(ctx, sentenceNumbers, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountLicenseUpdates provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaSentenceRepository) CountLicenseUpdates(ctx context.Context, params []service.TatoebaSentenceLicenseParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaSentenceLicenseParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaSentenceLicenseParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSentenceNumbersByLicense provides a mock function with given fields: ctx, license, lang3s, afterSentenceNumber, limit
+func (_m *TatoebaSentenceRepository) FindSentenceNumbersByLicense(ctx context.Context, license service.TatoebaSentenceLicense, lang3s []domain.Lang3, afterSentenceNumber int, limit int) ([]int, error) {
+	ret := _m.Called(ctx, license, lang3s, afterSentenceNumber, limit)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, service.TatoebaSentenceLicense, []domain.Lang3, int, int) []int); ok {
+		r0 = rf(ctx, license, lang3s, afterSentenceNumber, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.TatoebaSentenceLicense, []domain.Lang3, int, int) error); ok {
+		r1 = rf(ctx, license, lang3s, afterSentenceNumber, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindTatoebaSentenceBySentenceNumber provides a mock function with given fields: ctx, sentenceNumber
 func (_m *TatoebaSentenceRepository) FindTatoebaSentenceBySentenceNumber(ctx context.Context, sentenceNumber int) (service.TatoebaSentence, error) {
 	ret := _m.Called(ctx, sentenceNumber)
@@ -97,6 +186,177 @@ func (_m *TatoebaSentenceRepository) FindTatoebaSentencePairs(ctx context.Contex
 	return r0, r1
 }
 
+// FindTatoebaSentencesByLang3 provides a mock function with given fields: ctx, lang3, afterSentenceNumber, limit
+func (_m *TatoebaSentenceRepository) FindTatoebaSentencesByLang3(ctx context.Context, lang3 domain.Lang3, afterSentenceNumber int, limit int) ([]service.TatoebaSentence, error) {
+	ret := _m.Called(ctx, lang3, afterSentenceNumber, limit)
+
+	var r0 []service.TatoebaSentence
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Lang3, int, int) []service.TatoebaSentence); ok {
+		r0 = rf(ctx, lang3, afterSentenceNumber, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.TatoebaSentence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Lang3, int, int) error); ok {
+		r1 = rf(ctx, lang3, afterSentenceNumber, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTatoebaSentencesBySentenceNumbers provides a mock function with given fields: ctx, sentenceNumbers
+func (_m *TatoebaSentenceRepository) FindTatoebaSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int) ([]service.TatoebaSentence, error) {
+	ret := _m.Called(ctx, sentenceNumbers)
+
+	var r0 []service.TatoebaSentence
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []service.TatoebaSentence); ok {
+		r0 = rf(ctx, sentenceNumbers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.TatoebaSentence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, sentenceNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTranslationsBySentenceNumber provides a mock function with given fields: ctx, sentenceNumber, includeIndirect
+func (_m *TatoebaSentenceRepository) FindTranslationsBySentenceNumber(ctx context.Context, sentenceNumber int, includeIndirect bool) ([]service.TatoebaSentencePair, error) {
+	ret := _m.Called(ctx, sentenceNumber, includeIndirect)
+
+	var r0 []service.TatoebaSentencePair
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) []service.TatoebaSentencePair); ok {
+		r0 = rf(ctx, sentenceNumber, includeIndirect)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.TatoebaSentencePair)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, bool) error); ok {
+		r1 = rf(ctx, sentenceNumber, includeIndirect)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreviewSyncBatch provides a mock function with given fields: ctx, params
+func (_m *TatoebaSentenceRepository) PreviewSyncBatch(ctx context.Context, params []service.TatoebaSentenceAddParameter) ([]service.TatoebaSentenceSyncResult, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []service.TatoebaSentenceSyncResult
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaSentenceAddParameter) []service.TatoebaSentenceSyncResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.TatoebaSentenceSyncResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaSentenceAddParameter) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTatoebaSentences provides a mock function with given fields: ctx, sentenceNumbers
+func (_m *TatoebaSentenceRepository) RemoveTatoebaSentences(ctx context.Context, sentenceNumbers []int) (int, error) {
+	ret := _m.Called(ctx, sentenceNumbers)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) int); ok {
+		r0 = rf(ctx, sentenceNumbers)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, sentenceNumbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncBatch provides a mock function with given fields: ctx, params
+func (_m *TatoebaSentenceRepository) SyncBatch(ctx context.Context, params []service.TatoebaSentenceAddParameter) ([]service.TatoebaSentenceSyncResult, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []service.TatoebaSentenceSyncResult
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaSentenceAddParameter) []service.TatoebaSentenceSyncResult); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.TatoebaSentenceSyncResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaSentenceAddParameter) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDifficultiesAndWordCounts provides a mock function with given fields: ctx, params
+func (_m *TatoebaSentenceRepository) UpdateDifficultiesAndWordCounts(ctx context.Context, params []service.TatoebaSentenceDifficultyParameter) error {
+	ret := _m.Called(ctx, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaSentenceDifficultyParameter) error); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLicenses provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaSentenceRepository) UpdateLicenses(ctx context.Context, params []service.TatoebaSentenceLicenseParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaSentenceLicenseParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaSentenceLicenseParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTatoebaSentenceRepository creates a new instance of TatoebaSentenceRepository. It also registers a cleanup function to assert the mocks expectations.
 func NewTatoebaSentenceRepository(t testing.TB) *TatoebaSentenceRepository {
 	mock := &TatoebaSentenceRepository{}
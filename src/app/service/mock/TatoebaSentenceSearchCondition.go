@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	domain "github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
@@ -13,15 +15,79 @@ type TatoebaSentenceSearchCondition struct {
 	mock.Mock
 }
 
+// GetCursor provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetCursor() service.TatoebaSentencePairCursor {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentencePairCursor
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentencePairCursor); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentencePairCursor)
+		}
+	}
+
+	return r0
+}
+
+// GetDstFilter provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetDstFilter() service.TatoebaSentenceFilter {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentenceFilter
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentenceFilter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentenceFilter)
+		}
+	}
+
+	return r0
+}
+
+// GetDstLang3 provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetDstLang3() domain.Lang3 {
+	ret := _m.Called()
+
+	var r0 domain.Lang3
+	if rf, ok := ret.Get(0).(func() domain.Lang3); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Lang3)
+		}
+	}
+
+	return r0
+}
+
 // GetKeyword provides a mock function with given fields:
-func (_m *TatoebaSentenceSearchCondition) GetKeyword() string {
+func (_m *TatoebaSentenceSearchCondition) GetKeyword() service.TatoebaSentenceKeywordCondition {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentenceKeywordCondition
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentenceKeywordCondition); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentenceKeywordCondition)
+		}
+	}
+
+	return r0
+}
+
+// GetMaxHops provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetMaxHops() int {
 	ret := _m.Called()
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(int)
 	}
 
 	return r0
@@ -55,6 +121,80 @@ func (_m *TatoebaSentenceSearchCondition) GetPageSize() int {
 	return r0
 }
 
+// GetSeed provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetSeed() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetSortBy provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetSortBy() service.SortBy {
+	ret := _m.Called()
+
+	var r0 service.SortBy
+	if rf, ok := ret.Get(0).(func() service.SortBy); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.SortBy)
+	}
+
+	return r0
+}
+
+// GetSortOrder provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetSortOrder() service.SortOrder {
+	ret := _m.Called()
+
+	var r0 service.SortOrder
+	if rf, ok := ret.Get(0).(func() service.SortOrder); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.SortOrder)
+	}
+
+	return r0
+}
+
+// GetSrcFilter provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetSrcFilter() service.TatoebaSentenceFilter {
+	ret := _m.Called()
+
+	var r0 service.TatoebaSentenceFilter
+	if rf, ok := ret.Get(0).(func() service.TatoebaSentenceFilter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaSentenceFilter)
+		}
+	}
+
+	return r0
+}
+
+// GetSrcLang3 provides a mock function with given fields:
+func (_m *TatoebaSentenceSearchCondition) GetSrcLang3() domain.Lang3 {
+	ret := _m.Called()
+
+	var r0 domain.Lang3
+	if rf, ok := ret.Get(0).(func() domain.Lang3); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Lang3)
+		}
+	}
+
+	return r0
+}
+
 // IsRandom provides a mock function with given fields:
 func (_m *TatoebaSentenceSearchCondition) IsRandom() bool {
 	ret := _m.Called()
@@ -0,0 +1,51 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaTagAddParameter is an autogenerated mock type for the TatoebaTagAddParameter type
+type TatoebaTagAddParameter struct {
+	mock.Mock
+}
+
+// GetSentenceNumber provides a mock function with given fields:
+func (_m *TatoebaTagAddParameter) GetSentenceNumber() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetTagName provides a mock function with given fields:
+func (_m *TatoebaTagAddParameter) GetTagName() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NewTatoebaTagAddParameter creates a new instance of TatoebaTagAddParameter. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaTagAddParameter(t testing.TB) *TatoebaTagAddParameter {
+	mock := &TatoebaTagAddParameter{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,49 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaTagAddParameterIterator is an autogenerated mock type for the TatoebaTagAddParameterIterator type
+type TatoebaTagAddParameterIterator struct {
+	mock.Mock
+}
+
+// Next provides a mock function with given fields: ctx
+func (_m *TatoebaTagAddParameterIterator) Next(ctx context.Context) (service.TatoebaTagAddParameter, error) {
+	ret := _m.Called(ctx)
+
+	var r0 service.TatoebaTagAddParameter
+	if rf, ok := ret.Get(0).(func(context.Context) service.TatoebaTagAddParameter); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaTagAddParameter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaTagAddParameterIterator creates a new instance of TatoebaTagAddParameterIterator. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaTagAddParameterIterator(t testing.TB) *TatoebaTagAddParameterIterator {
+	mock := &TatoebaTagAddParameterIterator{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	service "github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// TatoebaTagRepository is an autogenerated mock type for the TatoebaTagRepository type
+type TatoebaTagRepository struct {
+	mock.Mock
+}
+
+// AddBatch provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaTagRepository) AddBatch(ctx context.Context, params []service.TatoebaTagAddParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaTagAddParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaTagAddParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountNewTags provides a mock function with given fields: ctx, params, lang3s
+func (_m *TatoebaTagRepository) CountNewTags(ctx context.Context, params []service.TatoebaTagAddParameter, lang3s []domain.Lang3) (int, error) {
+	ret := _m.Called(ctx, params, lang3s)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, []service.TatoebaTagAddParameter, []domain.Lang3) int); ok {
+		r0 = rf(ctx, params, lang3s)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []service.TatoebaTagAddParameter, []domain.Lang3) error); ok {
+		r1 = rf(ctx, params, lang3s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountTagsBySentenceNumber provides a mock function with given fields: ctx, sentenceNumber, keptTagNames
+func (_m *TatoebaTagRepository) CountTagsBySentenceNumber(ctx context.Context, sentenceNumber int, keptTagNames []string) (int, error) {
+	ret := _m.Called(ctx, sentenceNumber, keptTagNames)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) int); ok {
+		r0 = rf(ctx, sentenceNumber, keptTagNames)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []string) error); ok {
+		r1 = rf(ctx, sentenceNumber, keptTagNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountTagsBySentenceNumberRange provides a mock function with given fields: ctx, afterSentenceNumber, beforeSentenceNumber
+func (_m *TatoebaTagRepository) CountTagsBySentenceNumberRange(ctx context.Context, afterSentenceNumber int, beforeSentenceNumber int) (int, error) {
+	ret := _m.Called(ctx, afterSentenceNumber, beforeSentenceNumber)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, afterSentenceNumber, beforeSentenceNumber)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, afterSentenceNumber, beforeSentenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTagsBySentenceNumber provides a mock function with given fields: ctx, sentenceNumber, keptTagNames
+func (_m *TatoebaTagRepository) RemoveTagsBySentenceNumber(ctx context.Context, sentenceNumber int, keptTagNames []string) (int, error) {
+	ret := _m.Called(ctx, sentenceNumber, keptTagNames)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) int); ok {
+		r0 = rf(ctx, sentenceNumber, keptTagNames)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []string) error); ok {
+		r1 = rf(ctx, sentenceNumber, keptTagNames)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTagsBySentenceNumberRange provides a mock function with given fields: ctx, afterSentenceNumber, beforeSentenceNumber
+func (_m *TatoebaTagRepository) RemoveTagsBySentenceNumberRange(ctx context.Context, afterSentenceNumber int, beforeSentenceNumber int) (int, error) {
+	ret := _m.Called(ctx, afterSentenceNumber, beforeSentenceNumber)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, afterSentenceNumber, beforeSentenceNumber)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, afterSentenceNumber, beforeSentenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTatoebaTagRepository creates a new instance of TatoebaTagRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaTagRepository(t testing.TB) *TatoebaTagRepository {
+	mock := &TatoebaTagRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
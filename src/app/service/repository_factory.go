@@ -1,3 +1,4 @@
+//go:generate mockery --output mock --name RepositoryFactory
 package service
 
 import (
@@ -8,4 +9,10 @@ type RepositoryFactory interface {
 	NewTatoebaLinkRepository(ctx context.Context) (TatoebaLinkRepository, error)
 
 	NewTatoebaSentenceRepository(ctx context.Context) (TatoebaSentenceRepository, error)
+
+	NewTatoebaTagRepository(ctx context.Context) (TatoebaTagRepository, error)
+
+	NewTatoebaAudioRepository(ctx context.Context) (TatoebaAudioRepository, error)
+
+	NewImportJobRepository(ctx context.Context) (ImportJobRepository, error)
 }
@@ -0,0 +1,145 @@
+//go:generate mockery --output mock --name TatoebaAudio
+//go:generate mockery --output mock --name TatoebaAudioAddParameter
+//go:generate mockery --output mock --name TatoebaAudioRepository
+package service
+
+import (
+	"context"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+// TatoebaAudio is a recording of a sentence by a native speaker
+type TatoebaAudio interface {
+	GetAudioID() int
+	GetSentenceNumber() int
+	// GetAuthor returns the username of the speaker
+	GetAuthor() string
+	// GetLicense returns the license of the recording. Empty means that the recording cannot be reused
+	GetLicense() string
+	// GetAttributionURL returns the page to credit the speaker. Empty means the profile of the speaker on Tatoeba
+	GetAttributionURL() string
+}
+
+type tatoebaAudio struct {
+	AudioID        int `validate:"required"`
+	SentenceNumber int `validate:"required"`
+	Author         string
+	License        string
+	AttributionURL string
+}
+
+func NewTatoebaAudio(audioID, sentenceNumber int, author, license, attributionURL string) (TatoebaAudio, error) {
+	m := &tatoebaAudio{
+		AudioID:        audioID,
+		SentenceNumber: sentenceNumber,
+		Author:         author,
+		License:        license,
+		AttributionURL: attributionURL,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (m *tatoebaAudio) GetAudioID() int {
+	return m.AudioID
+}
+
+func (m *tatoebaAudio) GetSentenceNumber() int {
+	return m.SentenceNumber
+}
+
+func (m *tatoebaAudio) GetAuthor() string {
+	return m.Author
+}
+
+func (m *tatoebaAudio) GetLicense() string {
+	return m.License
+}
+
+func (m *tatoebaAudio) GetAttributionURL() string {
+	return m.AttributionURL
+}
+
+type TatoebaAudioAddParameter interface {
+	GetAudioID() int
+	GetSentenceNumber() int
+	GetAuthor() string
+	GetLicense() string
+	GetAttributionURL() string
+}
+
+type tatoebaAudioAddParameter struct {
+	AudioID        int    `validate:"required"`
+	SentenceNumber int    `validate:"required"`
+	Author         string `validate:"required,max=100"`
+	License        string `validate:"max=100"`
+	AttributionURL string `validate:"max=1000"`
+}
+
+func NewTatoebaAudioAddParameter(audioID, sentenceNumber int, author, license, attributionURL string) (TatoebaAudioAddParameter, error) {
+	m := &tatoebaAudioAddParameter{
+		AudioID:        audioID,
+		SentenceNumber: sentenceNumber,
+		Author:         author,
+		License:        license,
+		AttributionURL: attributionURL,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (p *tatoebaAudioAddParameter) GetAudioID() int {
+	return p.AudioID
+}
+
+func (p *tatoebaAudioAddParameter) GetSentenceNumber() int {
+	return p.SentenceNumber
+}
+
+func (p *tatoebaAudioAddParameter) GetAuthor() string {
+	return p.Author
+}
+
+func (p *tatoebaAudioAddParameter) GetLicense() string {
+	return p.License
+}
+
+func (p *tatoebaAudioAddParameter) GetAttributionURL() string {
+	return p.AttributionURL
+}
+
+// TatoebaAudioSyncResult is how Sync changed the audio
+type TatoebaAudioSyncResult int
+
+const (
+	TatoebaAudioUnchanged TatoebaAudioSyncResult = iota
+	TatoebaAudioInserted
+	TatoebaAudioUpdated
+)
+
+type TatoebaAudioRepository interface {
+	// FindTatoebaAudiosBySentenceNumbers returns the audios of the sentences in order of audio ID, keyed by the sentence number
+	FindTatoebaAudiosBySentenceNumbers(ctx context.Context, sentenceNumbers []int) (map[int][]TatoebaAudio, error)
+
+	// FindTatoebaAudios returns the audios of the sentences of lang3s in order of audio ID, starting after the audio ID.
+	// All languages if lang3s is empty
+	FindTatoebaAudios(ctx context.Context, lang3s []domain.Lang3, afterAudioID, limit int) ([]TatoebaAudio, error)
+
+	// AddBatch adds the audios of the existing sentences with multi-row inserts and returns the number of added audios. Existing audios are ignored.
+	// The sentences must be of lang3s unless lang3s is empty
+	AddBatch(ctx context.Context, params []TatoebaAudioAddParameter, lang3s []domain.Lang3) (int, error)
+
+	// CountNewAudios returns the number of audios which AddBatch would add without adding them
+	CountNewAudios(ctx context.Context, params []TatoebaAudioAddParameter, lang3s []domain.Lang3) (int, error)
+
+	// Sync adds the audio or updates its sentence, author, license and attribution URL. The sentence must exist
+	Sync(ctx context.Context, param TatoebaAudioAddParameter) (TatoebaAudioSyncResult, error)
+
+	// PreviewSync returns the result which Sync would return without writing the audio
+	PreviewSync(ctx context.Context, param TatoebaAudioAddParameter) (TatoebaAudioSyncResult, error)
+
+	// RemoveTatoebaAudios removes the audios and returns the number of removed audios
+	RemoveTatoebaAudios(ctx context.Context, audioIDs []int) (int, error)
+}
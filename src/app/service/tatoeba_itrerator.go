@@ -1,13 +1,56 @@
 //go:generate mockery --output mock --name TatoebaLinkAddParameterIterator
 //go:generate mockery --output mock --name TatoebaSentenceAddParameterIterator
+//go:generate mockery --output mock --name TatoebaTagAddParameterIterator
+//go:generate mockery --output mock --name TatoebaAudioAddParameterIterator
+//go:generate mockery --output mock --name TatoebaSentenceLicenseParameterIterator
 package service
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
+
+// RejectCode classifies why a row is rejected
+type RejectCode string
+
+const (
+	// RejectCodeMalformed is a row which cannot be parsed
+	RejectCodeMalformed RejectCode = "malformed"
+	// RejectCodeTextTooLong is a well-formed sentence whose text is longer than the limit of the import
+	RejectCodeTextTooLong RejectCode = "text_too_long"
+)
+
+// RejectedRowError is returned by iterators for a row which cannot be imported. The iterator can continue reading after it
+type RejectedRowError struct {
+	RowNumber int
+	Code      RejectCode
+	Reason    string
+}
+
+func (e *RejectedRowError) Error() string {
+	return fmt.Sprintf("row rejected. rowNumber: %d, code: %s, reason: %s", e.RowNumber, e.Code, e.Reason)
+}
 
 type TatoebaLinkAddParameterIterator interface {
 	Next(ctx context.Context) (TatoebaLinkAddParameter, error)
 }
 
 type TatoebaSentenceAddParameterIterator interface {
+	// Next returns the next sentence. It returns RejectedRowError for a malformed row or a text longer than the limit, and io.EOF at the end
 	Next(ctx context.Context) (TatoebaSentenceAddParameter, error)
 }
+
+type TatoebaTagAddParameterIterator interface {
+	// Next returns the next tag. It returns RejectedRowError for a malformed row and io.EOF at the end
+	Next(ctx context.Context) (TatoebaTagAddParameter, error)
+}
+
+type TatoebaAudioAddParameterIterator interface {
+	// Next returns the next audio. It returns RejectedRowError for a malformed row and io.EOF at the end
+	Next(ctx context.Context) (TatoebaAudioAddParameter, error)
+}
+
+type TatoebaSentenceLicenseParameterIterator interface {
+	// Next returns the license of the next sentence. It returns RejectedRowError for a malformed row and io.EOF at the end
+	Next(ctx context.Context) (TatoebaSentenceLicenseParameter, error)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
 )
 
@@ -40,4 +41,27 @@ func (p *tatoebaLinkAddParameter) GetTo() int {
 
 type TatoebaLinkRepository interface {
 	Add(ctx context.Context, param TatoebaLinkAddParameter) error
+
+	// AddBatch adds the links whose sentences exist with multi-row inserts and returns the number of added links. Existing links are ignored.
+	// Both sentences must be of lang3s unless lang3s is empty
+	AddBatch(ctx context.Context, params []TatoebaLinkAddParameter, lang3s []domain.Lang3) (int, error)
+
+	// CountNewLinks returns the number of links which AddBatch would add without adding them
+	CountNewLinks(ctx context.Context, params []TatoebaLinkAddParameter, lang3s []domain.Lang3) (int, error)
+
+	// RemoveLinksByFrom removes the links from the sentence except those to keptTos and returns the number of removed links
+	RemoveLinksByFrom(ctx context.Context, from int, keptTos []int) (int, error)
+
+	// CountLinksByFrom returns the number of links which RemoveLinksByFrom would remove
+	CountLinksByFrom(ctx context.Context, from int, keptTos []int) (int, error)
+
+	// RemoveLinksByFromRange removes the links whose source is between afterFrom and beforeFrom, exclusive, and returns the number of removed links.
+	// beforeFrom 0 means no upper bound
+	RemoveLinksByFromRange(ctx context.Context, afterFrom, beforeFrom int) (int, error)
+
+	// CountLinksByFromRange returns the number of links which RemoveLinksByFromRange would remove
+	CountLinksByFromRange(ctx context.Context, afterFrom, beforeFrom int) (int, error)
+
+	// RefreshSentencePairCounts recomputes the number of linked sentence pairs per language pair
+	RefreshSentencePairCounts(ctx context.Context) error
 }
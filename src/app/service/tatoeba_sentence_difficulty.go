@@ -0,0 +1,40 @@
+//go:generate mockery --output mock --name TatoebaSentenceDifficultyParameter
+package service
+
+import (
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+// TatoebaSentenceDifficultyParameter is the difficulty and the word count computed for a sentence
+type TatoebaSentenceDifficultyParameter interface {
+	GetSentenceNumber() int
+	GetDifficulty() int
+	GetWordCount() int
+}
+
+type tatoebaSentenceDifficultyParameter struct {
+	SentenceNumber int `validate:"required"`
+	Difficulty     int `validate:"gte=0,lte=100"`
+	WordCount      int `validate:"gte=0"`
+}
+
+func NewTatoebaSentenceDifficultyParameter(sentenceNumber, difficulty, wordCount int) (TatoebaSentenceDifficultyParameter, error) {
+	m := &tatoebaSentenceDifficultyParameter{
+		SentenceNumber: sentenceNumber,
+		Difficulty:     difficulty,
+		WordCount:      wordCount,
+	}
+	return m, libD.Validator.Struct(m)
+}
+
+func (p *tatoebaSentenceDifficultyParameter) GetSentenceNumber() int {
+	return p.SentenceNumber
+}
+
+func (p *tatoebaSentenceDifficultyParameter) GetDifficulty() int {
+	return p.Difficulty
+}
+
+func (p *tatoebaSentenceDifficultyParameter) GetWordCount() int {
+	return p.WordCount
+}
@@ -0,0 +1,132 @@
+//go:generate mockery --output mock --name TatoebaSentenceFilter
+package service
+
+import (
+	"time"
+
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+// TatoebaSentenceFilter is the condition which each sentence of a pair must satisfy
+type TatoebaSentenceFilter interface {
+	GetMinDifficulty() int
+	GetMaxDifficulty() int
+	// GetMinLength returns the minimum number of characters. 0 means no limit
+	GetMinLength() int
+	// GetMaxLength returns the maximum number of characters. 0 means no limit
+	GetMaxLength() int
+	// GetMinWordCount returns the minimum number of words. 0 means no limit
+	GetMinWordCount() int
+	// GetMaxWordCount returns the maximum number of words. 0 means no limit
+	GetMaxWordCount() int
+	// GetIncludeAuthors returns the authors one of whom must have written the sentence. Empty means no limit
+	GetIncludeAuthors() []string
+	// GetExcludeAuthors returns the authors who must not have written the sentence
+	GetExcludeAuthors() []string
+	// GetIncludeTags returns the tags one of which the sentence must have. Empty means no limit
+	GetIncludeTags() []string
+	// GetExcludeTags returns the tags which the sentence must not have
+	GetExcludeTags() []string
+	// IsWithAudioOnly returns whether the sentence must have a recording
+	IsWithAudioOnly() bool
+	// GetLicense returns the license of the sentence. Empty means no limit
+	GetLicense() TatoebaSentenceLicense
+	// GetUpdatedAfter returns the time after which the sentence must have been updated. nil means no limit
+	GetUpdatedAfter() *time.Time
+	// GetUpdatedBefore returns the time before which the sentence must have been updated. nil means no limit
+	GetUpdatedBefore() *time.Time
+}
+
+type tatoebaSentenceFilter struct {
+	MinDifficulty  int      `validate:"gte=0,lte=100"`
+	MaxDifficulty  int      `validate:"gte=0,lte=100,gtefield=MinDifficulty"`
+	MinLength      int      `validate:"gte=0"`
+	MaxLength      int      `validate:"omitempty,gtefield=MinLength"`
+	MinWordCount   int      `validate:"gte=0"`
+	MaxWordCount   int      `validate:"omitempty,gtefield=MinWordCount"`
+	IncludeAuthors []string `validate:"dive,required"`
+	ExcludeAuthors []string `validate:"dive,required"`
+	IncludeTags    []string `validate:"dive,required"`
+	ExcludeTags    []string `validate:"dive,required"`
+	WithAudioOnly  bool
+	License        TatoebaSentenceLicense `validate:"omitempty,oneof=CC-BY-2.0-FR CC0-1.0"`
+	UpdatedAfter   *time.Time
+	UpdatedBefore  *time.Time
+}
+
+func NewTatoebaSentenceFilter(minDifficulty, maxDifficulty, minLength, maxLength, minWordCount, maxWordCount int, includeAuthors, excludeAuthors, includeTags, excludeTags []string, withAudioOnly bool, license TatoebaSentenceLicense, updatedAfter, updatedBefore *time.Time) (TatoebaSentenceFilter, error) {
+	m := &tatoebaSentenceFilter{
+		MinDifficulty:  minDifficulty,
+		MaxDifficulty:  maxDifficulty,
+		MinLength:      minLength,
+		MaxLength:      maxLength,
+		MinWordCount:   minWordCount,
+		MaxWordCount:   maxWordCount,
+		IncludeAuthors: includeAuthors,
+		ExcludeAuthors: excludeAuthors,
+		IncludeTags:    includeTags,
+		ExcludeTags:    excludeTags,
+		WithAudioOnly:  withAudioOnly,
+		License:        license,
+		UpdatedAfter:   updatedAfter,
+		UpdatedBefore:  updatedBefore,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (f *tatoebaSentenceFilter) GetMinDifficulty() int {
+	return f.MinDifficulty
+}
+
+func (f *tatoebaSentenceFilter) GetMaxDifficulty() int {
+	return f.MaxDifficulty
+}
+
+func (f *tatoebaSentenceFilter) GetMinLength() int {
+	return f.MinLength
+}
+
+func (f *tatoebaSentenceFilter) GetMaxLength() int {
+	return f.MaxLength
+}
+
+func (f *tatoebaSentenceFilter) GetMinWordCount() int {
+	return f.MinWordCount
+}
+
+func (f *tatoebaSentenceFilter) GetMaxWordCount() int {
+	return f.MaxWordCount
+}
+
+func (f *tatoebaSentenceFilter) GetIncludeAuthors() []string {
+	return f.IncludeAuthors
+}
+
+func (f *tatoebaSentenceFilter) GetExcludeAuthors() []string {
+	return f.ExcludeAuthors
+}
+
+func (f *tatoebaSentenceFilter) GetIncludeTags() []string {
+	return f.IncludeTags
+}
+
+func (f *tatoebaSentenceFilter) GetExcludeTags() []string {
+	return f.ExcludeTags
+}
+
+func (f *tatoebaSentenceFilter) IsWithAudioOnly() bool {
+	return f.WithAudioOnly
+}
+
+func (f *tatoebaSentenceFilter) GetLicense() TatoebaSentenceLicense {
+	return f.License
+}
+
+func (f *tatoebaSentenceFilter) GetUpdatedAfter() *time.Time {
+	return f.UpdatedAfter
+}
+
+func (f *tatoebaSentenceFilter) GetUpdatedBefore() *time.Time {
+	return f.UpdatedBefore
+}
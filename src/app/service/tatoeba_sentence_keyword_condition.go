@@ -0,0 +1,76 @@
+//go:generate mockery --output mock --name TatoebaSentenceKeywordCondition
+package service
+
+import (
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+// MatchMode is how the keyword is matched against the text of sentences
+type MatchMode string
+
+const (
+	// MatchModeSubstring matches sentences which contain the keyword
+	MatchModeSubstring MatchMode = "substring"
+	// MatchModeWord matches sentences which contain all the words of the keyword
+	MatchModeWord MatchMode = "word"
+	// MatchModePhrase matches sentences which contain the words of the keyword in order
+	MatchModePhrase MatchMode = "phrase"
+	// MatchModePrefix matches sentences which contain words starting with each word of the keyword
+	MatchModePrefix MatchMode = "prefix"
+)
+
+// KeywordTarget is the side of a sentence pair which the keyword is applied to
+type KeywordTarget string
+
+const (
+	// KeywordTargetSrc matches the keyword against the source sentence
+	KeywordTargetSrc KeywordTarget = "src"
+	// KeywordTargetDst matches the keyword against the destination sentence
+	KeywordTargetDst KeywordTarget = "dst"
+	// KeywordTargetEither matches the keyword against the source or the destination sentence
+	KeywordTargetEither KeywordTarget = "either"
+	// KeywordTargetBoth matches the keyword against the source sentence and the dst keyword against the destination sentence
+	KeywordTargetBoth KeywordTarget = "both"
+)
+
+type TatoebaSentenceKeywordCondition interface {
+	GetKeyword() string
+	// GetDstKeyword returns the keyword for the destination sentence. It is used only if the target is KeywordTargetBoth
+	GetDstKeyword() string
+	GetMatchMode() MatchMode
+	GetTarget() KeywordTarget
+}
+
+type tatoebaSentenceKeywordCondition struct {
+	Keyword    string        `validate:"required"`
+	DstKeyword string        `validate:"required_if=Target both"`
+	MatchMode  MatchMode     `validate:"oneof=substring word phrase prefix"`
+	Target     KeywordTarget `validate:"oneof=src dst either both"`
+}
+
+func NewTatoebaSentenceKeywordCondition(keyword, dstKeyword string, matchMode MatchMode, target KeywordTarget) (TatoebaSentenceKeywordCondition, error) {
+	m := &tatoebaSentenceKeywordCondition{
+		Keyword:    keyword,
+		DstKeyword: dstKeyword,
+		MatchMode:  matchMode,
+		Target:     target,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (c *tatoebaSentenceKeywordCondition) GetKeyword() string {
+	return c.Keyword
+}
+
+func (c *tatoebaSentenceKeywordCondition) GetDstKeyword() string {
+	return c.DstKeyword
+}
+
+func (c *tatoebaSentenceKeywordCondition) GetMatchMode() MatchMode {
+	return c.MatchMode
+}
+
+func (c *tatoebaSentenceKeywordCondition) GetTarget() KeywordTarget {
+	return c.Target
+}
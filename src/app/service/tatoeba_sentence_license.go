@@ -0,0 +1,42 @@
+//go:generate mockery --output mock --name TatoebaSentenceLicenseParameter
+package service
+
+import (
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+// TatoebaSentenceLicense is the identifier of the license of a sentence
+type TatoebaSentenceLicense string
+
+const (
+	// TatoebaSentenceLicenseCCBY20FR is the license of the sentences by default
+	TatoebaSentenceLicenseCCBY20FR TatoebaSentenceLicense = "CC-BY-2.0-FR"
+	// TatoebaSentenceLicenseCC0 is the license of the sentences listed in sentences_CC0.csv
+	TatoebaSentenceLicenseCC0 TatoebaSentenceLicense = "CC0-1.0"
+)
+
+type TatoebaSentenceLicenseParameter interface {
+	GetSentenceNumber() int
+	GetLicense() TatoebaSentenceLicense
+}
+
+type tatoebaSentenceLicenseParameter struct {
+	SentenceNumber int                    `validate:"required"`
+	License        TatoebaSentenceLicense `validate:"oneof=CC-BY-2.0-FR CC0-1.0"`
+}
+
+func NewTatoebaSentenceLicenseParameter(sentenceNumber int, license TatoebaSentenceLicense) (TatoebaSentenceLicenseParameter, error) {
+	m := &tatoebaSentenceLicenseParameter{
+		SentenceNumber: sentenceNumber,
+		License:        license,
+	}
+	return m, libD.Validator.Struct(m)
+}
+
+func (p *tatoebaSentenceLicenseParameter) GetSentenceNumber() int {
+	return p.SentenceNumber
+}
+
+func (p *tatoebaSentenceLicenseParameter) GetLicense() TatoebaSentenceLicense {
+	return p.License
+}
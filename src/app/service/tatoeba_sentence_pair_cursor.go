@@ -0,0 +1,75 @@
+//go:generate mockery --output mock --name TatoebaSentencePairCursor
+package service
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+// TatoebaSentencePairCursor points at the last pair of a page. The next page starts right after it.
+type TatoebaSentencePairCursor interface {
+	GetSrcSentenceNumber() int
+	GetDstSentenceNumber() int
+	// String returns the opaque representation of the cursor
+	String() string
+}
+
+type tatoebaSentencePairCursor struct {
+	SrcSentenceNumber int `validate:"required"`
+	DstSentenceNumber int `validate:"required"`
+}
+
+func NewTatoebaSentencePairCursor(srcSentenceNumber, dstSentenceNumber int) (TatoebaSentencePairCursor, error) {
+	m := &tatoebaSentencePairCursor{
+		SrcSentenceNumber: srcSentenceNumber,
+		DstSentenceNumber: dstSentenceNumber,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+// ParseTatoebaSentencePairCursor decodes the value returned by TatoebaSentencePairCursor.String
+func ParseTatoebaSentencePairCursor(value string) (TatoebaSentencePairCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("cursor", err)
+	}
+
+	numbers := strings.Split(string(b), ",")
+	if len(numbers) != 2 {
+		return nil, libD.NewInvalidArgumentError("cursor", "it is not a cursor returned by the API")
+	}
+
+	src, err := strconv.Atoi(numbers[0])
+	if err != nil {
+		return nil, libD.NewInvalidArgumentError("cursor", "it is not a cursor returned by the API")
+	}
+
+	dst, err := strconv.Atoi(numbers[1])
+	if err != nil {
+		return nil, libD.NewInvalidArgumentError("cursor", "it is not a cursor returned by the API")
+	}
+
+	cursor, err := NewTatoebaSentencePairCursor(src, dst)
+	if err != nil {
+		return nil, libD.ToInvalidArgumentError("cursor", err)
+	}
+
+	return cursor, nil
+}
+
+func (c *tatoebaSentencePairCursor) GetSrcSentenceNumber() int {
+	return c.SrcSentenceNumber
+}
+
+func (c *tatoebaSentencePairCursor) GetDstSentenceNumber() int {
+	return c.DstSentenceNumber
+}
+
+func (c *tatoebaSentencePairCursor) String() string {
+	value := strconv.Itoa(c.SrcSentenceNumber) + "," + strconv.Itoa(c.DstSentenceNumber)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
@@ -0,0 +1,26 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+func Test_ParseTatoebaSentencePairCursor(t *testing.T) {
+	cursor, err := service.NewTatoebaSentencePairCursor(123, 456)
+	assert.NoError(t, err)
+
+	parsed, err := service.ParseTatoebaSentencePairCursor(cursor.String())
+	assert.NoError(t, err)
+	assert.Equal(t, 123, parsed.GetSrcSentenceNumber())
+	assert.Equal(t, 456, parsed.GetDstSentenceNumber())
+
+	for _, value := range []string{"!!!", "MTIz", "YSxi"} {
+		_, err := service.ParseTatoebaSentencePairCursor(value)
+		assert.True(t, errors.Is(err, libD.ErrInvalidArgument), value)
+	}
+}
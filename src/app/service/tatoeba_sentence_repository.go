@@ -24,6 +24,11 @@ type TatoebaSentence interface {
 	GetText() string
 	GetAuthor() string
 	GetUpdatedAt() time.Time
+	GetDifficulty() int
+	GetWordCount() int
+	GetLicense() TatoebaSentenceLicense
+	// GetAudios returns the recordings of the sentence. It is empty for sentences found for an internal use, like scoring difficulties
+	GetAudios() []TatoebaAudio
 }
 
 type tatoebaSentence struct {
@@ -32,15 +37,23 @@ type tatoebaSentence struct {
 	Text           string
 	Author         string
 	UpdatedAt      time.Time
+	Difficulty     int                    `validate:"gte=0,lte=100"`
+	WordCount      int                    `validate:"gte=0"`
+	License        TatoebaSentenceLicense `validate:"oneof=CC-BY-2.0-FR CC0-1.0"`
+	Audios         []TatoebaAudio
 }
 
-func NewTatoebaSentence(sentenceNumber int, lang3 domain.Lang3, text, author string, updatedAt time.Time) (TatoebaSentence, error) {
+func NewTatoebaSentence(sentenceNumber int, lang3 domain.Lang3, text, author string, updatedAt time.Time, difficulty, wordCount int, license TatoebaSentenceLicense, audios []TatoebaAudio) (TatoebaSentence, error) {
 	m := &tatoebaSentence{
 		SentenceNumber: sentenceNumber,
 		Lang3:          lang3,
 		Text:           text,
 		Author:         author,
 		UpdatedAt:      updatedAt,
+		Difficulty:     difficulty,
+		WordCount:      wordCount,
+		License:        license,
+		Audios:         audios,
 	}
 
 	return m, libD.Validator.Struct(m)
@@ -66,20 +79,43 @@ func (m *tatoebaSentence) GetUpdatedAt() time.Time {
 	return m.UpdatedAt
 }
 
+func (m *tatoebaSentence) GetDifficulty() int {
+	return m.Difficulty
+}
+
+func (m *tatoebaSentence) GetWordCount() int {
+	return m.WordCount
+}
+
+func (m *tatoebaSentence) GetLicense() TatoebaSentenceLicense {
+	return m.License
+}
+
+func (m *tatoebaSentence) GetAudios() []TatoebaAudio {
+	return m.Audios
+}
+
 type TatoebaSentencePair interface {
 	GetSrc() TatoebaSentence
 	GetDst() TatoebaSentence
+	// IsDirect returns whether the source sentence is linked to the destination sentence directly
+	IsDirect() bool
+	// GetPivot returns the sentence which links the source sentence to the destination sentence. nil means that the pair is direct
+	GetPivot() TatoebaSentence
 }
 
 type tatoebaSentencePair struct {
-	Src TatoebaSentence
-	Dst TatoebaSentence
+	Src   TatoebaSentence
+	Dst   TatoebaSentence
+	Pivot TatoebaSentence
 }
 
-func NewTatoebaSentencePair(src, dst TatoebaSentence) (TatoebaSentencePair, error) {
+// NewTatoebaSentencePair returns the pair of sentences. pivot is nil if the pair is linked directly
+func NewTatoebaSentencePair(src, dst, pivot TatoebaSentence) (TatoebaSentencePair, error) {
 	m := &tatoebaSentencePair{
-		Src: src,
-		Dst: dst,
+		Src:   src,
+		Dst:   dst,
+		Pivot: pivot,
 	}
 
 	return m, libD.Validator.Struct(m)
@@ -93,6 +129,14 @@ func (m *tatoebaSentencePair) GetDst() TatoebaSentence {
 	return m.Dst
 }
 
+func (m *tatoebaSentencePair) IsDirect() bool {
+	return m.Pivot == nil
+}
+
+func (m *tatoebaSentencePair) GetPivot() TatoebaSentence {
+	return m.Pivot
+}
+
 type TatoebaSentenceAddParameter interface {
 	GetSentenceNumber() int
 	GetLang3() domain.Lang3
@@ -141,26 +185,79 @@ func (p *tatoebaSentenceAddParameter) GetUpdatedAt() time.Time {
 	return p.UpdatedAt
 }
 
+// MaxSeed is the maximum seed of the random order
+const MaxSeed = 2147483646
+
+// SortBy is the order of sentence pairs. Pairs are sorted by the source sentence
+type SortBy string
+
+const (
+	SortBySentenceNumber SortBy = "sentenceNumber"
+	SortByUpdatedAt      SortBy = "updatedAt"
+	SortByTextLength     SortBy = "textLength"
+	SortByDifficulty     SortBy = "difficulty"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
 type TatoebaSentenceSearchCondition interface {
 	GetPageNo() int
 	GetPageSize() int
-	GetKeyword() string
+	// GetKeyword returns the keyword condition. nil means that sentences are not filtered by keywords
+	GetKeyword() TatoebaSentenceKeywordCondition
 	IsRandom() bool
+	// GetSeed returns the seed of the random order. Each seed shuffles the pairs in a different order. The same seed returns the same order
+	GetSeed() int
+	GetSrcLang3() domain.Lang3
+	GetDstLang3() domain.Lang3
+	// GetMaxHops returns the maximum number of links between the source and destination sentences. 2 includes pairs linked through a pivot sentence
+	GetMaxHops() int
+	// GetSrcFilter returns the filter of source sentences. nil means that source sentences are not filtered
+	GetSrcFilter() TatoebaSentenceFilter
+	// GetDstFilter returns the filter of destination sentences. nil means that destination sentences are not filtered
+	GetDstFilter() TatoebaSentenceFilter
+	GetSortBy() SortBy
+	GetSortOrder() SortOrder
+	// GetCursor returns the position to start after. nil means that pageNo is used
+	GetCursor() TatoebaSentencePairCursor
 }
 
 type tatoebaSentenceSearchCondition struct {
-	PageNo   int `validate:"required,gte=1"`
-	PageSize int `validate:"required,gte=1,lte=100"`
-	Keyword  string
-	Random   bool
-}
-
-func NewTatoebaSentenceSearchCondition(pageNo, pageSize int, keyword string, random bool) (TatoebaSentenceSearchCondition, error) {
+	PageNo    int `validate:"required,gte=1"`
+	PageSize  int `validate:"required,gte=1,lte=100"`
+	Keyword   TatoebaSentenceKeywordCondition
+	Random    bool
+	Seed      int          `validate:"gte=0,lte=2147483646"`
+	SrcLang3  domain.Lang3 `validate:"required"`
+	DstLang3  domain.Lang3 `validate:"required"`
+	MaxHops   int          `validate:"gte=1,lte=2"`
+	SrcFilter TatoebaSentenceFilter
+	DstFilter TatoebaSentenceFilter
+	SortBy    SortBy    `validate:"oneof=sentenceNumber updatedAt textLength difficulty"`
+	SortOrder SortOrder `validate:"oneof=asc desc"`
+	Cursor    TatoebaSentencePairCursor
+}
+
+func NewTatoebaSentenceSearchCondition(pageNo, pageSize int, keyword TatoebaSentenceKeywordCondition, random bool, seed int, srcLang3, dstLang3 domain.Lang3, maxHops int, srcFilter, dstFilter TatoebaSentenceFilter, sortBy SortBy, sortOrder SortOrder, cursor TatoebaSentencePairCursor) (TatoebaSentenceSearchCondition, error) {
 	m := &tatoebaSentenceSearchCondition{
-		PageNo:   pageNo,
-		PageSize: pageSize,
-		Keyword:  keyword,
-		Random:   random,
+		PageNo:    pageNo,
+		PageSize:  pageSize,
+		Keyword:   keyword,
+		Random:    random,
+		Seed:      seed,
+		SrcLang3:  srcLang3,
+		DstLang3:  dstLang3,
+		MaxHops:   maxHops,
+		SrcFilter: srcFilter,
+		DstFilter: dstFilter,
+		SortBy:    sortBy,
+		SortOrder: sortOrder,
+		Cursor:    cursor,
 	}
 
 	return m, libD.Validator.Struct(m)
@@ -174,7 +271,7 @@ func (c *tatoebaSentenceSearchCondition) GetPageSize() int {
 	return c.PageSize
 }
 
-func (c *tatoebaSentenceSearchCondition) GetKeyword() string {
+func (c *tatoebaSentenceSearchCondition) GetKeyword() TatoebaSentenceKeywordCondition {
 	return c.Keyword
 }
 
@@ -182,20 +279,64 @@ func (c *tatoebaSentenceSearchCondition) IsRandom() bool {
 	return c.Random
 }
 
+func (c *tatoebaSentenceSearchCondition) GetSeed() int {
+	return c.Seed
+}
+
+func (c *tatoebaSentenceSearchCondition) GetSrcLang3() domain.Lang3 {
+	return c.SrcLang3
+}
+
+func (c *tatoebaSentenceSearchCondition) GetDstLang3() domain.Lang3 {
+	return c.DstLang3
+}
+
+func (c *tatoebaSentenceSearchCondition) GetMaxHops() int {
+	return c.MaxHops
+}
+
+func (c *tatoebaSentenceSearchCondition) GetSrcFilter() TatoebaSentenceFilter {
+	return c.SrcFilter
+}
+
+func (c *tatoebaSentenceSearchCondition) GetDstFilter() TatoebaSentenceFilter {
+	return c.DstFilter
+}
+
+func (c *tatoebaSentenceSearchCondition) GetSortBy() SortBy {
+	return c.SortBy
+}
+
+func (c *tatoebaSentenceSearchCondition) GetSortOrder() SortOrder {
+	return c.SortOrder
+}
+
+func (c *tatoebaSentenceSearchCondition) GetCursor() TatoebaSentencePairCursor {
+	return c.Cursor
+}
+
 type TatoebaSentencePairSearchResult interface {
 	GetTotalCount() int
+	// IsTotalCountCapped returns true if the number of matching pairs exceeds the total count
+	IsTotalCountCapped() bool
 	GetResults() []TatoebaSentencePair
+	// GetNextCursor returns the position of the next page. nil means that there are no more pairs or that the pairs are not sorted by sentence number, including the random order
+	GetNextCursor() TatoebaSentencePairCursor
 }
 
 type tatoebaSentencePairSearchResult struct {
-	TotalCount int
-	Results    []TatoebaSentencePair
+	TotalCount       int
+	TotalCountCapped bool
+	Results          []TatoebaSentencePair
+	NextCursor       TatoebaSentencePairCursor
 }
 
-func NewTatoebaSentencePairSearchResult(totalCount int, results []TatoebaSentencePair) TatoebaSentencePairSearchResult {
+func NewTatoebaSentencePairSearchResult(totalCount int, totalCountCapped bool, results []TatoebaSentencePair, nextCursor TatoebaSentencePairCursor) TatoebaSentencePairSearchResult {
 	return &tatoebaSentencePairSearchResult{
-		TotalCount: totalCount,
-		Results:    results,
+		TotalCount:       totalCount,
+		TotalCountCapped: totalCountCapped,
+		Results:          results,
+		NextCursor:       nextCursor,
 	}
 }
 
@@ -203,16 +344,74 @@ func (r *tatoebaSentencePairSearchResult) GetTotalCount() int {
 	return r.TotalCount
 }
 
+func (r *tatoebaSentencePairSearchResult) IsTotalCountCapped() bool {
+	return r.TotalCountCapped
+}
+
 func (r *tatoebaSentencePairSearchResult) GetResults() []TatoebaSentencePair {
 	return r.Results
 }
 
+func (r *tatoebaSentencePairSearchResult) GetNextCursor() TatoebaSentencePairCursor {
+	return r.NextCursor
+}
+
+// TatoebaSentenceSyncResult is how Sync changed the sentence
+type TatoebaSentenceSyncResult int
+
+const (
+	TatoebaSentenceUnchanged TatoebaSentenceSyncResult = iota
+	TatoebaSentenceInserted
+	TatoebaSentenceUpdated
+)
+
 type TatoebaSentenceRepository interface {
 	FindTatoebaSentencePairs(ctx context.Context, param TatoebaSentenceSearchCondition) (TatoebaSentencePairSearchResult, error)
 
 	FindTatoebaSentenceBySentenceNumber(ctx context.Context, sentenceNumber int) (TatoebaSentence, error)
 
+	// FindTatoebaSentencesBySentenceNumbers returns the sentences found among the sentence numbers in no particular order
+	FindTatoebaSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int) ([]TatoebaSentence, error)
+
+	// FindTranslationsBySentenceNumber returns pairs of the sentence and its translations in order of language.
+	// Translations linked through a pivot sentence are included if includeIndirect is true
+	FindTranslationsBySentenceNumber(ctx context.Context, sentenceNumber int, includeIndirect bool) ([]TatoebaSentencePair, error)
+
+	// FindTatoebaSentencesByLang3 returns sentences of the language in order of sentence number, starting after the sentence number
+	FindTatoebaSentencesByLang3(ctx context.Context, lang3 domain.Lang3, afterSentenceNumber, limit int) ([]TatoebaSentence, error)
+
 	Add(ctx context.Context, param TatoebaSentenceAddParameter) error
 
+	// AddBatch adds the sentences with multi-row inserts and returns the number of added sentences. Existing sentences are ignored
+	AddBatch(ctx context.Context, params []TatoebaSentenceAddParameter) (int, error)
+
+	// SyncBatch adds the sentences or updates their language, text and author, and returns the result of each sentence in the order of the parameters.
+	// A sentence is updated only if the parameter is newer than the stored one and its contents differ
+	SyncBatch(ctx context.Context, params []TatoebaSentenceAddParameter) ([]TatoebaSentenceSyncResult, error)
+
+	// PreviewSyncBatch returns the results which SyncBatch would return without writing the sentences
+	PreviewSyncBatch(ctx context.Context, params []TatoebaSentenceAddParameter) ([]TatoebaSentenceSyncResult, error)
+
+	// RemoveTatoebaSentences removes the sentences with their links, tags and audios and returns the number of removed sentences
+	RemoveTatoebaSentences(ctx context.Context, sentenceNumbers []int) (int, error)
+
 	ContainsSentenceBySentenceNumber(ctx context.Context, sentenceNumber int) (bool, error)
+
+	// ContainsSentencesBySentenceNumbers returns the set of the existing sentences among the sentence numbers.
+	// Only sentences of lang3s are contained unless lang3s is empty
+	ContainsSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int, lang3s []domain.Lang3) (map[int]bool, error)
+
+	// UpdateDifficultiesAndWordCounts updates the difficulties and the word counts of the sentences in a statement
+	UpdateDifficultiesAndWordCounts(ctx context.Context, params []TatoebaSentenceDifficultyParameter) error
+
+	// UpdateLicenses updates the licenses of the existing sentences and returns the number of the sentences whose license changed.
+	// Only sentences of lang3s are updated unless lang3s is empty
+	UpdateLicenses(ctx context.Context, params []TatoebaSentenceLicenseParameter, lang3s []domain.Lang3) (int, error)
+
+	// CountLicenseUpdates returns the number of the sentences whose license UpdateLicenses would change without writing them
+	CountLicenseUpdates(ctx context.Context, params []TatoebaSentenceLicenseParameter, lang3s []domain.Lang3) (int, error)
+
+	// FindSentenceNumbersByLicense returns the numbers of the sentences with the license in ascending order, starting after the sentence number.
+	// Only sentences of lang3s are returned unless lang3s is empty
+	FindSentenceNumbersByLicense(ctx context.Context, license TatoebaSentenceLicense, lang3s []domain.Lang3, afterSentenceNumber, limit int) ([]int, error)
 }
@@ -0,0 +1,58 @@
+//go:generate mockery --output mock --name TatoebaTagAddParameter
+//go:generate mockery --output mock --name TatoebaTagRepository
+package service
+
+import (
+	"context"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+)
+
+type TatoebaTagAddParameter interface {
+	GetSentenceNumber() int
+	GetTagName() string
+}
+
+type tatoebaTagAddParameter struct {
+	SentenceNumber int    `validate:"required"`
+	TagName        string `validate:"required,max=100"`
+}
+
+func NewTatoebaTagAddParameter(sentenceNumber int, tagName string) (TatoebaTagAddParameter, error) {
+	m := &tatoebaTagAddParameter{
+		SentenceNumber: sentenceNumber,
+		TagName:        tagName,
+	}
+	return m, libD.Validator.Struct(m)
+}
+
+func (p *tatoebaTagAddParameter) GetSentenceNumber() int {
+	return p.SentenceNumber
+}
+
+func (p *tatoebaTagAddParameter) GetTagName() string {
+	return p.TagName
+}
+
+type TatoebaTagRepository interface {
+	// AddBatch adds the tags of the existing sentences with multi-row inserts and returns the number of added tags. Existing tags are ignored.
+	// The sentences must be of lang3s unless lang3s is empty
+	AddBatch(ctx context.Context, params []TatoebaTagAddParameter, lang3s []domain.Lang3) (int, error)
+
+	// CountNewTags returns the number of tags which AddBatch would add without adding them
+	CountNewTags(ctx context.Context, params []TatoebaTagAddParameter, lang3s []domain.Lang3) (int, error)
+
+	// RemoveTagsBySentenceNumber removes the tags of the sentence except keptTagNames and returns the number of removed tags
+	RemoveTagsBySentenceNumber(ctx context.Context, sentenceNumber int, keptTagNames []string) (int, error)
+
+	// CountTagsBySentenceNumber returns the number of tags which RemoveTagsBySentenceNumber would remove
+	CountTagsBySentenceNumber(ctx context.Context, sentenceNumber int, keptTagNames []string) (int, error)
+
+	// RemoveTagsBySentenceNumberRange removes the tags of the sentences between afterSentenceNumber and beforeSentenceNumber, exclusive,
+	// and returns the number of removed tags. beforeSentenceNumber 0 means no upper bound
+	RemoveTagsBySentenceNumberRange(ctx context.Context, afterSentenceNumber, beforeSentenceNumber int) (int, error)
+
+	// CountTagsBySentenceNumberRange returns the number of tags which RemoveTagsBySentenceNumberRange would remove
+	CountTagsBySentenceNumberRange(ctx context.Context, afterSentenceNumber, beforeSentenceNumber int) (int, error)
+}
@@ -3,10 +3,14 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
 	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-tatoeba-api/src/lib/log"
@@ -15,12 +19,59 @@ import (
 const (
 	commitSize = 1000
 	logSize    = 100000
+	// maxRejectedRows is the number of rejected rows kept for the report of a job. Rows after it are only counted
+	maxRejectedRows = 10000
+	// importJobHeartbeatInterval is the interval at which a running job renews its lease
+	importJobHeartbeatInterval = 30 * time.Second
+	// importJobLeaseDuration is how long a running job is alive without heartbeats. The job of a stopped process is failed after its lease expires
+	importJobLeaseDuration = 3 * importJobHeartbeatInterval
 )
 
 type AdminUsecase interface {
-	ImportSentences(ctx context.Context, iterator service.TatoebaSentenceAddParameterIterator) error
+	// ImportSentences starts the job which imports sentences in the background and returns the job ID. closer is closed when the job finishes.
+	// If the last import of the file with the same checksum and option did not succeed, the job resumes from its checkpoint.
+	// In ImportModeSync, sentences of the imported languages in the file are removed if they are absent from the file.
+	// A dry run job only reads the database and its counts are the numbers of the sentences which would be written
+	ImportSentences(ctx context.Context, iterator service.TatoebaSentenceAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error)
 
-	ImportLinks(ctx context.Context, iterator service.TatoebaLinkAddParameterIterator) error
+	// ImportLinks starts the job which imports links in the background and returns the job ID. closer is closed when the job finishes.
+	// If the last import of the file with the same checksum and option did not succeed, the job resumes from its checkpoint.
+	// In ImportModeSync, links absent from the file are removed, so the file must be sorted by the source sentence number.
+	// A dry run job only reads the database and checks the sentences of the links against it
+	ImportLinks(ctx context.Context, iterator service.TatoebaLinkAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error)
+
+	// ImportTags starts the job which imports tags of sentences in the background and returns the job ID. closer is closed when the job finishes.
+	// If the last import of the file with the same checksum and option did not succeed, the job resumes from its checkpoint.
+	// In ImportModeSync, tags absent from the file are removed, so the file must be sorted by the sentence number
+	ImportTags(ctx context.Context, iterator service.TatoebaTagAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error)
+
+	// ImportAudios starts the job which imports audio metadata of sentences in the background and returns the job ID. closer is closed when the job finishes.
+	// If the last import of the file with the same checksum and option did not succeed, the job resumes from its checkpoint.
+	// In ImportModeSync, audios of sentences of the imported languages are removed if they are absent from the file
+	ImportAudios(ctx context.Context, iterator service.TatoebaAudioAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error)
+
+	// ImportLicenses starts the job which updates the licenses of the existing sentences in the background and returns the job ID. closer is closed when the job finishes.
+	// If the last import of the file with the same checksum and option did not succeed, the job resumes from its checkpoint.
+	// In ImportModeSync, CC0 sentences of the imported languages absent from the file get the default license back
+	ImportLicenses(ctx context.Context, iterator service.TatoebaSentenceLicenseParameterIterator, closer io.Closer, option service.ImportOption) (int, error)
+
+	FindImportJob(ctx context.Context, id int) (service.ImportJob, error)
+
+	// CancelImportJob requests the running job to stop
+	CancelImportJob(ctx context.Context, id int) error
+
+	// FindImportJobRejectedRows returns the rows which the job rejected in order of row number
+	FindImportJobRejectedRows(ctx context.Context, id int) ([]service.ImportRejectedRow, error)
+}
+
+// importProgress is the counts saved at the checkpoint. readCount is the number of rows to skip when the job resumes
+type importProgress struct {
+	readCount   int
+	importCount int
+	updateCount int
+	deleteCount int
+	skipCount   int
+	rejectCount int
 }
 
 type adminUsecase struct {
@@ -35,12 +86,539 @@ func NewAdminUsecase(db *gorm.DB, rfFunc service.RepositoryFactoryFunc) AdminUse
 	}
 }
 
-func (u *adminUsecase) ImportSentences(ctx context.Context, iterator service.TatoebaSentenceAddParameterIterator) error {
+func (u *adminUsecase) ImportSentences(ctx context.Context, iterator service.TatoebaSentenceAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error) {
+	return u.startImportJob(ctx, service.ImportJobTypeSentence, option, closer, func(ctx context.Context, jobID int, progress importProgress) (bool, error) {
+		return u.importSentences(ctx, jobID, option, iterator, progress)
+	})
+}
+
+func (u *adminUsecase) ImportLinks(ctx context.Context, iterator service.TatoebaLinkAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error) {
+	return u.startImportJob(ctx, service.ImportJobTypeLink, option, closer, func(ctx context.Context, jobID int, progress importProgress) (bool, error) {
+		return u.importLinks(ctx, jobID, option, iterator, progress)
+	})
+}
+
+func (u *adminUsecase) ImportTags(ctx context.Context, iterator service.TatoebaTagAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error) {
+	return u.startImportJob(ctx, service.ImportJobTypeTag, option, closer, func(ctx context.Context, jobID int, progress importProgress) (bool, error) {
+		return u.importTags(ctx, jobID, option, iterator, progress)
+	})
+}
+
+func (u *adminUsecase) ImportAudios(ctx context.Context, iterator service.TatoebaAudioAddParameterIterator, closer io.Closer, option service.ImportOption) (int, error) {
+	return u.startImportJob(ctx, service.ImportJobTypeAudio, option, closer, func(ctx context.Context, jobID int, progress importProgress) (bool, error) {
+		return u.importAudios(ctx, jobID, option, iterator, progress)
+	})
+}
+
+func (u *adminUsecase) ImportLicenses(ctx context.Context, iterator service.TatoebaSentenceLicenseParameterIterator, closer io.Closer, option service.ImportOption) (int, error) {
+	return u.startImportJob(ctx, service.ImportJobTypeLicense, option, closer, func(ctx context.Context, jobID int, progress importProgress) (bool, error) {
+		return u.importLicenses(ctx, jobID, option, iterator, progress)
+	})
+}
+
+func (u *adminUsecase) FindImportJob(ctx context.Context, id int) (service.ImportJob, error) {
+	var result service.ImportJob
+	if err := u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewImportJobRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new ImportJobRepository. err: %w", err)
+		}
+
+		// a job whose process stopped would be shown as running forever
+		if err := u.failExpiredImportJobs(ctx, repo); err != nil {
+			return err
+		}
+
+		tmpResult, err := repo.FindImportJob(ctx, id)
+		if err != nil {
+			return liberrors.Errorf("execute FindImportJob. err: %w", err)
+		}
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (u *adminUsecase) CancelImportJob(ctx context.Context, id int) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewImportJobRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new ImportJobRepository. err: %w", err)
+		}
+
+		if err := repo.RequestImportJobCancel(ctx, id); err != nil {
+			return liberrors.Errorf("execute RequestImportJobCancel. err: %w", err)
+		}
+		return nil
+	})
+}
+
+func (u *adminUsecase) FindImportJobRejectedRows(ctx context.Context, id int) ([]service.ImportRejectedRow, error) {
+	var results []service.ImportRejectedRow
+	if err := u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewImportJobRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new ImportJobRepository. err: %w", err)
+		}
+
+		if _, err := repo.FindImportJob(ctx, id); err != nil {
+			return liberrors.Errorf("execute FindImportJob. err: %w", err)
+		}
+
+		tmpResults, err := repo.FindImportJobRejectedRows(ctx, id)
+		if err != nil {
+			return liberrors.Errorf("execute FindImportJobRejectedRows. err: %w", err)
+		}
+		results = tmpResults
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// startImportJob adds the job and runs fn in the background. fn returns true if the job is cancelled.
+// The job outlives the request, so it runs with a new context which keeps only the logger.
+func (u *adminUsecase) startImportJob(ctx context.Context, jobType service.ImportJobType, option service.ImportOption, closer io.Closer, fn func(ctx context.Context, jobID int, progress importProgress) (bool, error)) (int, error) {
+	var jobID int
+	var progress importProgress
+	if err := u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewImportJobRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new ImportJobRepository. err: %w", err)
+		}
+
+		if err := u.failExpiredImportJobs(ctx, repo); err != nil {
+			return err
+		}
+
+		resumedJob, err := u.findResumableImportJob(ctx, repo, jobType, option)
+		if err != nil {
+			return err
+		}
+
+		var resumedFromJobID *int
+		if resumedJob != nil {
+			id := resumedJob.GetID()
+			resumedFromJobID = &id
+			progress = importProgress{
+				readCount:   resumedJob.GetReadCount(),
+				importCount: resumedJob.GetImportCount(),
+				updateCount: resumedJob.GetUpdateCount(),
+				deleteCount: resumedJob.GetDeleteCount(),
+				skipCount:   resumedJob.GetSkipCount(),
+				rejectCount: resumedJob.GetRejectCount(),
+			}
+		}
+
+		tmpJobID, err := repo.AddImportJob(ctx, jobType, option, resumedFromJobID)
+		if err != nil {
+			return liberrors.Errorf("execute AddImportJob. err: %w", err)
+		}
+		jobID = tmpJobID
+
+		if resumedJob != nil {
+			if err := repo.UpdateImportJobProgress(ctx, jobID, progress.readCount, progress.importCount, progress.updateCount, progress.deleteCount, progress.skipCount, progress.rejectCount); err != nil {
+				return liberrors.Errorf("execute UpdateImportJobProgress. err: %w", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		closer.Close()
+		return 0, err
+	}
+
+	jobCtx := log.With(context.Background(), log.Str("import_job_id", strconv.Itoa(jobID)))
+	go func() {
+		logger := log.FromContext(jobCtx)
+		defer closer.Close()
+
+		done := make(chan struct{})
+		defer close(done)
+		go u.heartbeatImportJob(jobCtx, jobID, done)
+
+		if progress.readCount > 0 {
+			logger.Infof("import job resumes. read count: %d", progress.readCount)
+		}
+
+		status := service.ImportJobStatusSucceeded
+		errorMessage := ""
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					status = service.ImportJobStatusFailed
+					errorMessage = fmt.Sprintf("panic: %v", r)
+				}
+			}()
+
+			cancelled, err := fn(jobCtx, jobID, progress)
+			if err != nil {
+				status = service.ImportJobStatusFailed
+				errorMessage = err.Error()
+			} else if cancelled {
+				status = service.ImportJobStatusCancelled
+			}
+		}()
+
+		if status == service.ImportJobStatusFailed {
+			logger.Errorf("import job failed. err: %s", errorMessage)
+		}
+		logger.Infof("import job finished. status: %s", status)
+
+		if err := u.finishImportJob(jobCtx, jobID, status, errorMessage); err != nil {
+			logger.Errorf("failed to finish import job. err: %v", err)
+		}
+	}()
+
+	return jobID, nil
+}
+
+// heartbeatImportJob renews the lease of the job until done is closed. The job keeps running even if a heartbeat fails
+func (u *adminUsecase) heartbeatImportJob(ctx context.Context, jobID int, done <-chan struct{}) {
+	logger := log.FromContext(ctx)
+	ticker := time.NewTicker(importJobHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := u.db.Transaction(func(tx *gorm.DB) error {
+				rf, err := u.rfFunc(ctx, tx)
+				if err != nil {
+					return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+				}
+
+				repo, err := rf.NewImportJobRepository(ctx)
+				if err != nil {
+					return liberrors.Errorf("new ImportJobRepository. err: %w", err)
+				}
+
+				return repo.UpdateImportJobHeartbeat(ctx, jobID)
+			}); err != nil {
+				logger.Warnf("failed to update import job heartbeat. err: %v", err)
+			}
+		}
+	}
+}
+
+// failExpiredImportJobs fails the running jobs whose leases expired. Their processes stopped before finishing them, for example by a restart.
+// The lease is in the database, so the jobs running in the other processes are not failed
+func (u *adminUsecase) failExpiredImportJobs(ctx context.Context, repo service.ImportJobRepository) error {
+	count, err := repo.FailExpiredImportJobs(ctx, time.Now().Add(-importJobLeaseDuration), "interrupted")
+	if err != nil {
+		return liberrors.Errorf("execute FailExpiredImportJobs. err: %w", err)
+	}
+	if count > 0 {
+		log.FromContext(ctx).Warnf("failed interrupted import jobs. count: %d", count)
+	}
+	return nil
+}
+
+// findResumableImportJob returns the last import of the same file with the same option if it did not succeed, otherwise nil.
+// The interrupted jobs are already failed by failExpiredImportJobs, so a running job is alive in this or another process.
+func (u *adminUsecase) findResumableImportJob(ctx context.Context, repo service.ImportJobRepository, jobType service.ImportJobType, option service.ImportOption) (service.ImportJob, error) {
+	if option.GetFileChecksum() == "" {
+		return nil, nil
+	}
+
+	job, err := repo.FindLatestImportJobByFileChecksum(ctx, jobType, option.GetFileChecksum())
+	if errors.Is(err, service.ErrImportJobNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, liberrors.Errorf("execute FindLatestImportJobByFileChecksum. err: %w", err)
+	}
+
+	switch job.GetStatus() {
+	case service.ImportJobStatusSucceeded:
+		return nil, nil
+	case service.ImportJobStatusRunning:
+		return nil, liberrors.Errorf("id: %d, err: %w", job.GetID(), service.ErrImportJobAlreadyRunning)
+	}
+
+	if !job.GetOption().Equals(option) {
+		return nil, nil
+	}
+
+	return job, nil
+}
+
+func (u *adminUsecase) finishImportJob(ctx context.Context, jobID int, status service.ImportJobStatus, errorMessage string) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewImportJobRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new ImportJobRepository. err: %w", err)
+		}
+
+		return repo.FinishImportJob(ctx, jobID, status, errorMessage)
+	})
+}
+
+// updateImportJobProgress saves the counts and the rejected rows in the transaction of the batch and returns whether the cancellation is requested
+func (u *adminUsecase) updateImportJobProgress(ctx context.Context, rf service.RepositoryFactory, jobID int, progress importProgress, rejectedRows []service.ImportRejectedRow) (bool, error) {
+	repo, err := rf.NewImportJobRepository(ctx)
+	if err != nil {
+		return false, liberrors.Errorf("new ImportJobRepository. err: %w", err)
+	}
+
+	if err := repo.AddImportJobRejectedRows(ctx, jobID, rejectedRows); err != nil {
+		return false, err
+	}
+
+	if err := repo.UpdateImportJobProgress(ctx, jobID, progress.readCount, progress.importCount, progress.updateCount, progress.deleteCount, progress.skipCount, progress.rejectCount); err != nil {
+		return false, err
+	}
+
+	job, err := repo.FindImportJob(ctx, jobID)
+	if err != nil {
+		return false, err
+	}
+
+	return job.IsCancelRequested(), nil
+}
+
+func (u *adminUsecase) importSentences(ctx context.Context, jobID int, option service.ImportOption, iterator service.TatoebaSentenceAddParameterIterator, progress importProgress) (bool, error) {
+	// Lang3 is an interface, so the languages are keyed by the code
+	lang3s := make(map[string]domain.Lang3)
+	sentenceNumbers := numberSet{}
+
+	return u.runBatchImport(ctx, jobID, progress, &batchImport{
+		name: "sentence",
+		next: func(ctx context.Context) (interface{}, error) {
+			return iterator.Next(ctx)
+		},
+		// the languages of the rows before the checkpoint are still scored after the import
+		skip: func(ctx context.Context, row interface{}) error {
+			param := row.(service.TatoebaSentenceAddParameter)
+			if option.ContainsLang3(param.GetLang3()) {
+				lang3s[param.GetLang3().String()] = param.GetLang3()
+				sentenceNumbers.add(param.GetSentenceNumber())
+			}
+			return nil
+		},
+		importBatch: func(ctx context.Context, rf service.RepositoryFactory, rows []interface{}, last bool, progress *importProgress) error {
+			repo, err := rf.NewTatoebaSentenceRepository(ctx)
+			if err != nil {
+				return liberrors.Errorf("new TatoebaSentenceRepository. err: %w", err)
+			}
+
+			params := make([]service.TatoebaSentenceAddParameter, 0, len(rows))
+			for _, row := range rows {
+				param := row.(service.TatoebaSentenceAddParameter)
+				if !option.ContainsLang3(param.GetLang3()) {
+					progress.skipCount++
+					continue
+				}
+
+				lang3s[param.GetLang3().String()] = param.GetLang3()
+				duplicated := sentenceNumbers.contains(param.GetSentenceNumber())
+				sentenceNumbers.add(param.GetSentenceNumber())
+
+				// a dry run cannot rely on the database to ignore the sentences appearing twice in the file
+				if option.GetMode() != service.ImportModeSync && option.IsDryRun() && duplicated {
+					progress.skipCount++
+					continue
+				}
+				params = append(params, param)
+			}
+
+			if option.GetMode() == service.ImportModeSync {
+				return u.syncSentences(ctx, repo, params, option.IsDryRun(), progress)
+			}
+
+			importCount, err := u.addSentences(ctx, repo, params, option.IsDryRun())
+			if err != nil {
+				return err
+			}
+			progress.importCount += importCount
+			progress.skipCount += len(params) - importCount
+			return nil
+		},
+		finish: func(ctx context.Context, cancelled bool, progress *importProgress) error {
+			// sentences are removed only after the whole file is read
+			if option.GetMode() == service.ImportModeSync && !cancelled {
+				for _, lang3 := range lang3s {
+					removeCount, err := u.removeAbsentSentences(ctx, lang3, &sentenceNumbers, option.IsDryRun())
+					if err != nil {
+						return liberrors.Errorf("remove absent sentences. lang3: %s, err: %w", lang3.String(), err)
+					}
+					progress.deleteCount += removeCount
+				}
+
+				if !option.IsDryRun() {
+					if err := u.refreshSentencePairCounts(ctx); err != nil {
+						return err
+					}
+				}
+			}
+
+			// a cancelled job leaves the scores to the next import because scoring reads every sentence of the languages
+			if option.IsDryRun() || cancelled {
+				return nil
+			}
+
+			for _, lang3 := range lang3s {
+				if err := u.updateDifficulties(ctx, lang3); err != nil {
+					return liberrors.Errorf("update difficulties. lang3: %s, err: %w", lang3.String(), err)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// addSentences adds the sentences and returns the number of added sentences. A dry run counts the sentences absent from the database instead
+func (u *adminUsecase) addSentences(ctx context.Context, repo service.TatoebaSentenceRepository, params []service.TatoebaSentenceAddParameter, dryRun bool) (int, error) {
+	if !dryRun {
+		return repo.AddBatch(ctx, params)
+	}
+
+	sentenceNumbers := make([]int, len(params))
+	for i, param := range params {
+		sentenceNumbers[i] = param.GetSentenceNumber()
+	}
+
+	contained, err := repo.ContainsSentencesBySentenceNumbers(ctx, sentenceNumbers, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(params) - len(contained), nil
+}
+
+// syncSentences adds or updates the sentences of the batch and counts the results. A dry run counts the results without writing the sentences
+func (u *adminUsecase) syncSentences(ctx context.Context, repo service.TatoebaSentenceRepository, params []service.TatoebaSentenceAddParameter, dryRun bool, progress *importProgress) error {
+	syncBatch := repo.SyncBatch
+	if dryRun {
+		syncBatch = repo.PreviewSyncBatch
+	}
+
+	results, err := syncBatch(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		switch result {
+		case service.TatoebaSentenceInserted:
+			progress.importCount++
+		case service.TatoebaSentenceUpdated:
+			progress.updateCount++
+		default:
+			progress.skipCount++
+		}
+	}
+	return nil
+}
+
+// removeAbsentSentences removes the sentences of the language which are not in sentenceNumbers and returns the number of removed sentences.
+// A dry run only counts them
+func (u *adminUsecase) removeAbsentSentences(ctx context.Context, lang3 domain.Lang3, sentenceNumbers *numberSet, dryRun bool) (int, error) {
+	logger := log.FromContext(ctx)
+
+	removeCount := 0
+	if err := u.eachTatoebaSentencePage(ctx, lang3, func(repo service.TatoebaSentenceRepository, sentences []service.TatoebaSentence) error {
+		absentSentenceNumbers := make([]int, 0)
+		for _, sentence := range sentences {
+			if !sentenceNumbers.contains(sentence.GetSentenceNumber()) {
+				absentSentenceNumbers = append(absentSentenceNumbers, sentence.GetSentenceNumber())
+			}
+		}
+		if dryRun {
+			removeCount += len(absentSentenceNumbers)
+			return nil
+		}
+
+		count, err := repo.RemoveTatoebaSentences(ctx, absentSentenceNumbers)
+		if err != nil {
+			return err
+		}
+		removeCount += count
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	logger.Infof("removed sentence count. lang3: %s, count: %d", lang3.String(), removeCount)
+
+	return removeCount, nil
+}
+
+// updateDifficulties scores every sentence of the language.
+// The first pass counts word frequencies of the language and the second pass updates the changed scores and word counts, a page in a statement.
+func (u *adminUsecase) updateDifficulties(ctx context.Context, lang3 domain.Lang3) error {
 	logger := log.FromContext(ctx)
 
-	var readCount = 0
-	var importCount = 0
-	var skipCount = 0
+	frequency := domain.NewWordFrequency()
+	if err := u.eachTatoebaSentencePage(ctx, lang3, func(repo service.TatoebaSentenceRepository, sentences []service.TatoebaSentence) error {
+		for _, sentence := range sentences {
+			frequency.Add(sentence.GetText())
+		}
+		return nil
+	}); err != nil {
+		return liberrors.Errorf("count word frequencies. err: %w", err)
+	}
+
+	ranks := frequency.Ranks()
+	updateCount := 0
+	if err := u.eachTatoebaSentencePage(ctx, lang3, func(repo service.TatoebaSentenceRepository, sentences []service.TatoebaSentence) error {
+		params := make([]service.TatoebaSentenceDifficultyParameter, 0)
+		for _, sentence := range sentences {
+			difficulty := domain.ComputeDifficulty(sentence.GetText(), ranks)
+			wordCount := domain.CountWords(sentence.GetText())
+			if difficulty == sentence.GetDifficulty() && wordCount == sentence.GetWordCount() {
+				continue
+			}
+
+			param, err := service.NewTatoebaSentenceDifficultyParameter(sentence.GetSentenceNumber(), difficulty, wordCount)
+			if err != nil {
+				return err
+			}
+			params = append(params, param)
+		}
+
+		updateCount += len(params)
+		return repo.UpdateDifficultiesAndWordCounts(ctx, params)
+	}); err != nil {
+		return liberrors.Errorf("update difficulty. err: %w", err)
+	}
+
+	logger.Infof("updated difficulty count. lang3: %s, count: %d", lang3.String(), updateCount)
+
+	return nil
+}
+
+// eachTatoebaSentencePage calls fn for every page of commitSize sentences of the language. Each page is processed in a transaction
+func (u *adminUsecase) eachTatoebaSentencePage(ctx context.Context, lang3 domain.Lang3, fn func(repo service.TatoebaSentenceRepository, sentences []service.TatoebaSentence) error) error {
+	var lastSentenceNumber = 0
 	var loop = true
 	for loop {
 		if err := u.db.Transaction(func(tx *gorm.DB) error {
@@ -54,58 +632,296 @@ func (u *adminUsecase) ImportSentences(ctx context.Context, iterator service.Tat
 				return liberrors.Errorf("new TatoebaSentenceRepository. err: %w", err)
 			}
 
-			i := 0
-			for {
-				param, err := iterator.Next(ctx)
-				if errors.Is(err, io.EOF) {
-					loop = false
-					break
+			sentences, err := repo.FindTatoebaSentencesByLang3(ctx, lang3, lastSentenceNumber, commitSize)
+			if err != nil {
+				return liberrors.Errorf("find sentences. err: %w", err)
+			}
+
+			if len(sentences) == 0 {
+				loop = false
+				return nil
+			}
+
+			if err := fn(repo, sentences); err != nil {
+				return err
+			}
+			lastSentenceNumber = sentences[len(sentences)-1].GetSentenceNumber()
+
+			if len(sentences) < commitSize {
+				loop = false
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *adminUsecase) importLinks(ctx context.Context, jobID int, option service.ImportOption, iterator service.TatoebaLinkAddParameterIterator, progress importProgress) (bool, error) {
+	var sync *linkSync
+	if option.GetMode() == service.ImportModeSync {
+		sync = &linkSync{dryRun: option.IsDryRun()}
+	}
+
+	return u.runBatchImport(ctx, jobID, progress, &batchImport{
+		name: "link",
+		next: func(ctx context.Context) (interface{}, error) {
+			return iterator.Next(ctx)
+		},
+		// the links of the last source before the checkpoint are still compared when the next source appears
+		skip: func(ctx context.Context, row interface{}) error {
+			if sync == nil {
+				return nil
+			}
+			_, err := sync.add(ctx, nil, row.(service.TatoebaLinkAddParameter))
+			return err
+		},
+		importBatch: func(ctx context.Context, rf service.RepositoryFactory, rows []interface{}, last bool, progress *importProgress) error {
+			repo, err := rf.NewTatoebaLinkRepository(ctx)
+			if err != nil {
+				return liberrors.Errorf("new TatoebaLinkRepository. err: %w", err)
+			}
+
+			params := make([]service.TatoebaLinkAddParameter, len(rows))
+			for i, row := range rows {
+				params[i] = row.(service.TatoebaLinkAddParameter)
+				if sync != nil {
+					removeCount, err := sync.add(ctx, repo, params[i])
+					if err != nil {
+						return liberrors.Errorf("sync links. err: %w", err)
+					}
+					progress.deleteCount += removeCount
 				}
-				readCount++
+			}
+
+			// links whose sentences do not exist or are not of the languages of the option, and existing links are skipped
+			addBatch := repo.AddBatch
+			if option.IsDryRun() {
+				addBatch = repo.CountNewLinks
+			}
+			importCount, err := addBatch(ctx, params, option.GetLang3s())
+			if err != nil {
+				return liberrors.Errorf("add links. err: %w", err)
+			}
+			progress.importCount += importCount
+			progress.skipCount += len(params) - importCount
+
+			if last && sync != nil {
+				removeCount, err := sync.finish(ctx, repo)
 				if err != nil {
-					return liberrors.Errorf("read next line. read count: %d, err: %w", readCount, err)
+					return liberrors.Errorf("sync links. err: %w", err)
 				}
+				progress.deleteCount += removeCount
+			}
+			return nil
+		},
+		finish: func(ctx context.Context, cancelled bool, progress *importProgress) error {
+			if option.IsDryRun() {
+				return nil
+			}
+			return u.refreshSentencePairCounts(ctx)
+		},
+	})
+}
 
-				if param == nil {
-					skipCount++
-					continue
+// refreshSentencePairCounts recounts the pairs after links or sentences are changed
+func (u *adminUsecase) refreshSentencePairCounts(ctx context.Context) error {
+	if err := u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewTatoebaLinkRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new TatoebaLinkRepository. err: %w", err)
+		}
+
+		return repo.RefreshSentencePairCounts(ctx)
+	}); err != nil {
+		return liberrors.Errorf("refresh sentence pair counts. err: %w", err)
+	}
+	return nil
+}
+
+func (u *adminUsecase) importTags(ctx context.Context, jobID int, option service.ImportOption, iterator service.TatoebaTagAddParameterIterator, progress importProgress) (bool, error) {
+	var sync *tagSync
+	if option.GetMode() == service.ImportModeSync {
+		sync = &tagSync{dryRun: option.IsDryRun()}
+	}
+
+	return u.runBatchImport(ctx, jobID, progress, &batchImport{
+		name: "tag",
+		next: func(ctx context.Context) (interface{}, error) {
+			return iterator.Next(ctx)
+		},
+		// the tags of the last sentence before the checkpoint are still compared when the next sentence appears
+		skip: func(ctx context.Context, row interface{}) error {
+			if sync == nil {
+				return nil
+			}
+			_, err := sync.add(ctx, nil, row.(service.TatoebaTagAddParameter))
+			return err
+		},
+		importBatch: func(ctx context.Context, rf service.RepositoryFactory, rows []interface{}, last bool, progress *importProgress) error {
+			repo, err := rf.NewTatoebaTagRepository(ctx)
+			if err != nil {
+				return liberrors.Errorf("new TatoebaTagRepository. err: %w", err)
+			}
+
+			params := make([]service.TatoebaTagAddParameter, len(rows))
+			for i, row := range rows {
+				params[i] = row.(service.TatoebaTagAddParameter)
+				if sync != nil {
+					removeCount, err := sync.add(ctx, repo, params[i])
+					if err != nil {
+						return liberrors.Errorf("sync tags. err: %w", err)
+					}
+					progress.deleteCount += removeCount
 				}
+			}
 
-				if err := repo.Add(ctx, param); err != nil {
-					logger.Warnf("failed to Add. read count: %d, err: %v", readCount, err)
-					skipCount++
-					continue
+			// tags of sentences which do not exist or are not of the languages of the option, and existing tags are skipped
+			addBatch := repo.AddBatch
+			if option.IsDryRun() {
+				addBatch = repo.CountNewTags
+			}
+			importCount, err := addBatch(ctx, params, option.GetLang3s())
+			if err != nil {
+				return liberrors.Errorf("add tags. err: %w", err)
+			}
+			progress.importCount += importCount
+			progress.skipCount += len(params) - importCount
+
+			if last && sync != nil {
+				removeCount, err := sync.finish(ctx, repo)
+				if err != nil {
+					return liberrors.Errorf("sync tags. err: %w", err)
 				}
+				progress.deleteCount += removeCount
+			}
+			return nil
+		},
+	})
+}
 
-				i++
-				importCount++
-				if i >= commitSize {
-					if importCount%logSize == 0 {
-						logger.Infof("imported count: %d", importCount)
-					}
-					break
+func (u *adminUsecase) importAudios(ctx context.Context, jobID int, option service.ImportOption, iterator service.TatoebaAudioAddParameterIterator, progress importProgress) (bool, error) {
+	audioIDs := numberSet{}
+
+	return u.runBatchImport(ctx, jobID, progress, &batchImport{
+		name: "audio",
+		next: func(ctx context.Context) (interface{}, error) {
+			return iterator.Next(ctx)
+		},
+		// the audios read before the checkpoint are still kept from the removal in ImportModeSync
+		skip: func(ctx context.Context, row interface{}) error {
+			audioIDs.add(row.(service.TatoebaAudioAddParameter).GetAudioID())
+			return nil
+		},
+		importBatch: func(ctx context.Context, rf service.RepositoryFactory, rows []interface{}, last bool, progress *importProgress) error {
+			repo, err := rf.NewTatoebaAudioRepository(ctx)
+			if err != nil {
+				return liberrors.Errorf("new TatoebaAudioRepository. err: %w", err)
+			}
+
+			params := make([]service.TatoebaAudioAddParameter, len(rows))
+			for i, row := range rows {
+				params[i] = row.(service.TatoebaAudioAddParameter)
+				audioIDs.add(params[i].GetAudioID())
+			}
+
+			if option.GetMode() == service.ImportModeSync {
+				if err := u.syncAudios(ctx, rf, repo, params, option, progress); err != nil {
+					return liberrors.Errorf("sync audios. err: %w", err)
 				}
+				return nil
 			}
 
+			// audios of sentences which do not exist or are not of the languages of the option, and existing audios are skipped
+			addBatch := repo.AddBatch
+			if option.IsDryRun() {
+				addBatch = repo.CountNewAudios
+			}
+			importCount, err := addBatch(ctx, params, option.GetLang3s())
+			if err != nil {
+				return liberrors.Errorf("add audios. err: %w", err)
+			}
+			progress.importCount += importCount
+			progress.skipCount += len(params) - importCount
 			return nil
-		}); err != nil {
-			return liberrors.Errorf("import sentence. err: %w", err)
-		}
+		},
+		finish: func(ctx context.Context, cancelled bool, progress *importProgress) error {
+			// audios are removed only after the whole file is read
+			if option.GetMode() != service.ImportModeSync || cancelled {
+				return nil
+			}
+
+			removeCount, err := u.removeAbsentAudios(ctx, option, &audioIDs)
+			if err != nil {
+				return liberrors.Errorf("remove absent audios. err: %w", err)
+			}
+			progress.deleteCount += removeCount
+			return nil
+		},
+	})
+}
+
+// syncAudios adds or updates the audios of the existing sentences of the languages of the option and counts the results.
+// A dry run counts the results without writing the audios
+func (u *adminUsecase) syncAudios(ctx context.Context, rf service.RepositoryFactory, repo service.TatoebaAudioRepository, params []service.TatoebaAudioAddParameter, option service.ImportOption, progress *importProgress) error {
+	sentenceRepo, err := rf.NewTatoebaSentenceRepository(ctx)
+	if err != nil {
+		return liberrors.Errorf("new TatoebaSentenceRepository. err: %w", err)
+	}
+
+	sentenceNumbers := make([]int, len(params))
+	for i, param := range params {
+		sentenceNumbers[i] = param.GetSentenceNumber()
 	}
 
-	logger.Infof("imported count: %d", importCount)
-	logger.Infof("skipped count: %d", skipCount)
-	logger.Infof("read count: %d", readCount)
+	contained, err := sentenceRepo.ContainsSentencesBySentenceNumbers(ctx, sentenceNumbers, option.GetLang3s())
+	if err != nil {
+		return err
+	}
+
+	sync := repo.Sync
+	if option.IsDryRun() {
+		sync = repo.PreviewSync
+	}
+
+	for _, param := range params {
+		if !contained[param.GetSentenceNumber()] {
+			progress.skipCount++
+			continue
+		}
+
+		result, err := sync(ctx, param)
+		if err != nil {
+			return err
+		}
+
+		switch result {
+		case service.TatoebaAudioInserted:
+			progress.importCount++
+		case service.TatoebaAudioUpdated:
+			progress.updateCount++
+		default:
+			progress.skipCount++
+		}
+	}
 
 	return nil
 }
 
-func (u *adminUsecase) ImportLinks(ctx context.Context, iterator service.TatoebaLinkAddParameterIterator) error {
+// removeAbsentAudios removes the audios of the sentences of the languages of the option which are not in audioIDs and returns the number of removed audios.
+// A dry run only counts them
+func (u *adminUsecase) removeAbsentAudios(ctx context.Context, option service.ImportOption, audioIDs *numberSet) (int, error) {
 	logger := log.FromContext(ctx)
 
-	var readCount = 0
-	var importCount = 0
-	var skipCount = 0
+	removeCount := 0
+	var lastAudioID = 0
 	var loop = true
 	for loop {
 		if err := u.db.Transaction(func(tx *gorm.DB) error {
@@ -114,53 +930,159 @@ func (u *adminUsecase) ImportLinks(ctx context.Context, iterator service.Tatoeba
 				return liberrors.Errorf("create RepositoryFactory. err: %w", err)
 			}
 
-			repo, err := rf.NewTatoebaLinkRepository(ctx)
+			repo, err := rf.NewTatoebaAudioRepository(ctx)
 			if err != nil {
-				return liberrors.Errorf("new TatoebaLinkRepository. err: %w", err)
+				return liberrors.Errorf("new TatoebaAudioRepository. err: %w", err)
 			}
 
-			i := 0
-			for {
-				param, err := iterator.Next(ctx)
-				if errors.Is(err, io.EOF) {
-					loop = false
-					break
+			audios, err := repo.FindTatoebaAudios(ctx, option.GetLang3s(), lastAudioID, commitSize)
+			if err != nil {
+				return liberrors.Errorf("find audios. err: %w", err)
+			}
+
+			absentAudioIDs := make([]int, 0)
+			for _, audio := range audios {
+				if !audioIDs.contains(audio.GetAudioID()) {
+					absentAudioIDs = append(absentAudioIDs, audio.GetAudioID())
 				}
-				readCount++
+				lastAudioID = audio.GetAudioID()
+			}
+
+			if option.IsDryRun() {
+				removeCount += len(absentAudioIDs)
+			} else {
+				count, err := repo.RemoveTatoebaAudios(ctx, absentAudioIDs)
 				if err != nil {
-					return liberrors.Errorf("read next line. read count: %d, err: %w", readCount, err)
-				}
-				if param == nil {
-					skipCount++
-					continue
+					return err
 				}
+				removeCount += count
+			}
+
+			if len(audios) < commitSize {
+				loop = false
+			}
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	logger.Infof("removed audio count: %d", removeCount)
+
+	return removeCount, nil
+}
+
+func (u *adminUsecase) importLicenses(ctx context.Context, jobID int, option service.ImportOption, iterator service.TatoebaSentenceLicenseParameterIterator, progress importProgress) (bool, error) {
+	sentenceNumbers := numberSet{}
 
-				if err := repo.Add(ctx, param); err != nil {
-					if !errors.Is(err, service.ErrTatoebaSentenceNotFound) {
-						logger.Warnf("failed to Add. read count: %d, err: %v", readCount, err)
+	return u.runBatchImport(ctx, jobID, progress, &batchImport{
+		name: "license",
+		next: func(ctx context.Context) (interface{}, error) {
+			return iterator.Next(ctx)
+		},
+		// the sentences read before the checkpoint still keep their licenses in ImportModeSync
+		skip: func(ctx context.Context, row interface{}) error {
+			sentenceNumbers.add(row.(service.TatoebaSentenceLicenseParameter).GetSentenceNumber())
+			return nil
+		},
+		importBatch: func(ctx context.Context, rf service.RepositoryFactory, rows []interface{}, last bool, progress *importProgress) error {
+			repo, err := rf.NewTatoebaSentenceRepository(ctx)
+			if err != nil {
+				return liberrors.Errorf("new TatoebaSentenceRepository. err: %w", err)
+			}
+
+			params := make([]service.TatoebaSentenceLicenseParameter, len(rows))
+			for i, row := range rows {
+				params[i] = row.(service.TatoebaSentenceLicenseParameter)
+				sentenceNumbers.add(params[i].GetSentenceNumber())
+			}
+
+			// sentences which do not exist, are not of the languages of the option or already have the license are skipped
+			updateLicenses := repo.UpdateLicenses
+			if option.IsDryRun() {
+				updateLicenses = repo.CountLicenseUpdates
+			}
+			updateCount, err := updateLicenses(ctx, params, option.GetLang3s())
+			if err != nil {
+				return liberrors.Errorf("update licenses. err: %w", err)
+			}
+			progress.updateCount += updateCount
+			progress.skipCount += len(params) - updateCount
+			return nil
+		},
+		finish: func(ctx context.Context, cancelled bool, progress *importProgress) error {
+			// licenses are reset only after the whole file is read
+			if option.GetMode() != service.ImportModeSync || cancelled {
+				return nil
+			}
+
+			resetCount, err := u.resetAbsentCC0Licenses(ctx, option, &sentenceNumbers)
+			if err != nil {
+				return liberrors.Errorf("reset absent CC0 licenses. err: %w", err)
+			}
+			progress.updateCount += resetCount
+			return nil
+		},
+	})
+}
+
+// resetAbsentCC0Licenses gives the default license back to the CC0 sentences of the languages of the option which are not in sentenceNumbers and returns the number of them.
+// A dry run only counts them
+func (u *adminUsecase) resetAbsentCC0Licenses(ctx context.Context, option service.ImportOption, sentenceNumbers *numberSet) (int, error) {
+	logger := log.FromContext(ctx)
+
+	resetCount := 0
+	var lastSentenceNumber = 0
+	var loop = true
+	for loop {
+		if err := u.db.Transaction(func(tx *gorm.DB) error {
+			rf, err := u.rfFunc(ctx, tx)
+			if err != nil {
+				return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+			}
+
+			repo, err := rf.NewTatoebaSentenceRepository(ctx)
+			if err != nil {
+				return liberrors.Errorf("new TatoebaSentenceRepository. err: %w", err)
+			}
+
+			cc0SentenceNumbers, err := repo.FindSentenceNumbersByLicense(ctx, service.TatoebaSentenceLicenseCC0, option.GetLang3s(), lastSentenceNumber, commitSize)
+			if err != nil {
+				return liberrors.Errorf("find CC0 sentences. err: %w", err)
+			}
+
+			params := make([]service.TatoebaSentenceLicenseParameter, 0)
+			for _, sentenceNumber := range cc0SentenceNumbers {
+				if !sentenceNumbers.contains(sentenceNumber) {
+					param, err := service.NewTatoebaSentenceLicenseParameter(sentenceNumber, service.TatoebaSentenceLicenseCCBY20FR)
+					if err != nil {
+						return err
 					}
-					skipCount++
-					continue
+					params = append(params, param)
 				}
-				i++
-				importCount++
-				if i >= commitSize {
-					if importCount%logSize == 0 {
-						logger.Infof("imported count: %d", importCount)
-					}
-					break
+				lastSentenceNumber = sentenceNumber
+			}
+
+			if option.IsDryRun() {
+				resetCount += len(params)
+			} else {
+				count, err := repo.UpdateLicenses(ctx, params, nil)
+				if err != nil {
+					return err
 				}
+				resetCount += count
 			}
 
+			if len(cc0SentenceNumbers) < commitSize {
+				loop = false
+			}
 			return nil
 		}); err != nil {
-			return liberrors.Errorf("import sentence. err: %w", err)
+			return 0, err
 		}
 	}
 
-	logger.Infof("imported count: %d", importCount)
-	logger.Infof("skipped count: %d", skipCount)
-	logger.Infof("read count: %d", readCount)
+	logger.Infof("reset license count: %d", resetCount)
 
-	return nil
+	return resetCount, nil
 }
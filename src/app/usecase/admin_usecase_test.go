@@ -0,0 +1,249 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	gormSQLite "gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/domain"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	mocks "github.com/kujilabo/cocotola-tatoeba-api/src/app/service/mock"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/usecase"
+)
+
+const testJobID = 2
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+type importJobResult struct {
+	status       service.ImportJobStatus
+	errorMessage string
+}
+
+// newAdminUsecaseForTest returns the usecase whose repositories are mocks. The database only provides the transactions.
+// The other repositories are added to the factory by each test. The result of the job is sent to the channel when the job finishes
+func newAdminUsecaseForTest(t *testing.T, jobType service.ImportJobType) (usecase.AdminUsecase, *mocks.RepositoryFactory, *mocks.ImportJobRepository, chan importJobResult) {
+	db, err := gorm.Open(gormSQLite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	jobRepo := mocks.NewImportJobRepository(t)
+	rf := new(mocks.RepositoryFactory)
+	rf.On("NewImportJobRepository", mock.Anything).Return(jobRepo, nil)
+
+	jobRepo.On("FailExpiredImportJobs", mock.Anything, mock.Anything, "interrupted").Return(0, nil)
+	result := make(chan importJobResult, 1)
+	jobRepo.On("AddImportJob", mock.Anything, jobType, mock.Anything, mock.Anything).Return(testJobID, nil)
+	jobRepo.On("FinishImportJob", mock.Anything, testJobID, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		result <- importJobResult{status: args.Get(2).(service.ImportJobStatus), errorMessage: args.Get(3).(string)}
+	})
+
+	rfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return rf, nil
+	}
+	return usecase.NewAdminUsecase(db, rfFunc), rf, jobRepo, result
+}
+
+func newTagRepositoryForTest(t *testing.T, rf *mocks.RepositoryFactory) *mocks.TatoebaTagRepository {
+	tagRepo := mocks.NewTatoebaTagRepository(t)
+	rf.On("NewTatoebaTagRepository", mock.Anything).Return(tagRepo, nil)
+	return tagRepo
+}
+
+// newTagIterator returns the iterator of the rows. A nil row is rejected
+func newTagIterator(t *testing.T, rows ...service.TatoebaTagAddParameter) service.TatoebaTagAddParameterIterator {
+	iterator := new(mocks.TatoebaTagAddParameterIterator)
+	for i, row := range rows {
+		if row == nil {
+			iterator.On("Next", mock.Anything).Return(nil, &service.RejectedRowError{RowNumber: i + 1, Code: service.RejectCodeMalformed, Reason: "empty tag name"}).Once()
+			continue
+		}
+		iterator.On("Next", mock.Anything).Return(row, nil).Once()
+	}
+	iterator.On("Next", mock.Anything).Return(nil, io.EOF)
+	return iterator
+}
+
+func newTag(t *testing.T, sentenceNumber int, tagName string) service.TatoebaTagAddParameter {
+	param, err := service.NewTatoebaTagAddParameter(sentenceNumber, tagName)
+	require.NoError(t, err)
+	return param
+}
+
+// onRejectedRows records the rejected rows of the job. The usecase reuses the slice after the call, so the rows are copied
+func onRejectedRows(jobRepo *mocks.ImportJobRepository) *[]service.ImportRejectedRow {
+	rejectedRows := make([]service.ImportRejectedRow, 0)
+	jobRepo.On("AddImportJobRejectedRows", mock.Anything, testJobID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		rejectedRows = append(rejectedRows, args.Get(2).([]service.ImportRejectedRow)...)
+	})
+	return &rejectedRows
+}
+
+func onCancelRequested(t *testing.T, jobRepo *mocks.ImportJobRepository, option service.ImportOption) {
+	job, err := service.NewImportJob(testJobID, service.ImportJobTypeTag, option, service.ImportJobStatusRunning, 0, 0, 0, 0, 0, 0, "", false, time.Now(), nil, nil)
+	require.NoError(t, err)
+	jobRepo.On("FindImportJob", mock.Anything, testJobID).Return(job, nil)
+}
+
+func waitImportJob(t *testing.T, result chan importJobResult) {
+	select {
+	case r := <-result:
+		assert.Equal(t, service.ImportJobStatusSucceeded, r.status, r.errorMessage)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the import job did not finish")
+	}
+}
+
+func Test_adminUsecase_ImportTags_resume(t *testing.T) {
+	ctx := context.Background()
+	u, rf, jobRepo, result := newAdminUsecaseForTest(t, service.ImportJobTypeTag)
+	tagRepo := newTagRepositoryForTest(t, rf)
+
+	option, err := service.NewImportOption("checksum", service.ImportModeInsert, nil, false)
+	require.NoError(t, err)
+	onCancelRequested(t, jobRepo, option)
+	rejectedRows := onRejectedRows(jobRepo)
+
+	// the last job failed after the batch of the first two rows. read: 2, imported: 1, skipped: 1, rejected: 1
+	failedJob, err := service.NewImportJob(1, service.ImportJobTypeTag, option, service.ImportJobStatusFailed, 2, 1, 0, 0, 1, 1, "failed", false, time.Now(), nil, nil)
+	require.NoError(t, err)
+	jobRepo.On("FindLatestImportJobByFileChecksum", mock.Anything, service.ImportJobTypeTag, "checksum").Return(failedJob, nil)
+	jobRepo.On("UpdateImportJobProgress", mock.Anything, testJobID, 2, 1, 0, 0, 1, 1).Return(nil)
+
+	tag1 := newTag(t, 1, "a")
+	tag3 := newTag(t, 3, "b")
+	// only the rows after the checkpoint are imported
+	tagRepo.On("AddBatch", mock.Anything, []service.TatoebaTagAddParameter{tag3}, []domain.Lang3(nil)).Return(1, nil)
+	jobRepo.On("UpdateImportJobProgress", mock.Anything, testJobID, 3, 2, 0, 0, 1, 1).Return(nil)
+
+	_, err = u.ImportTags(ctx, newTagIterator(t, tag1, nil, tag3), nopCloser{}, option)
+	require.NoError(t, err)
+	waitImportJob(t, result)
+
+	// the rejected rows before the checkpoint are reported again without being counted twice
+	require.Len(t, *rejectedRows, 1)
+	assert.Equal(t, 2, (*rejectedRows)[0].GetRowNumber())
+}
+
+func Test_adminUsecase_ImportTags_rejectedRows(t *testing.T) {
+	ctx := context.Background()
+	u, rf, jobRepo, result := newAdminUsecaseForTest(t, service.ImportJobTypeTag)
+	tagRepo := newTagRepositoryForTest(t, rf)
+
+	option, err := service.NewImportOption("", service.ImportModeInsert, nil, false)
+	require.NoError(t, err)
+	onCancelRequested(t, jobRepo, option)
+	rejectedRows := onRejectedRows(jobRepo)
+
+	tag1 := newTag(t, 1, "a")
+	tag3 := newTag(t, 3, "b")
+	// the rejected row is skipped and the valid rows are imported in a batch
+	tagRepo.On("AddBatch", mock.Anything, []service.TatoebaTagAddParameter{tag1, tag3}, []domain.Lang3(nil)).Return(2, nil)
+	// read: 3, imported: 2, skipped: 1, rejected: 1
+	jobRepo.On("UpdateImportJobProgress", mock.Anything, testJobID, 3, 2, 0, 0, 1, 1).Return(nil)
+
+	jobID, err := u.ImportTags(ctx, newTagIterator(t, tag1, nil, tag3), nopCloser{}, option)
+	require.NoError(t, err)
+	assert.Equal(t, testJobID, jobID)
+	waitImportJob(t, result)
+
+	require.Len(t, *rejectedRows, 1)
+	assert.Equal(t, 2, (*rejectedRows)[0].GetRowNumber())
+	assert.Equal(t, service.RejectCodeMalformed, (*rejectedRows)[0].GetCode())
+	assert.Equal(t, "empty tag name", (*rejectedRows)[0].GetReason())
+}
+
+func Test_adminUsecase_ImportTags_dryRun(t *testing.T) {
+	ctx := context.Background()
+	u, rf, jobRepo, result := newAdminUsecaseForTest(t, service.ImportJobTypeTag)
+	tagRepo := newTagRepositoryForTest(t, rf)
+
+	option, err := service.NewImportOption("", service.ImportModeInsert, nil, true)
+	require.NoError(t, err)
+	onCancelRequested(t, jobRepo, option)
+	onRejectedRows(jobRepo)
+
+	tag1 := newTag(t, 1, "a")
+	tag2 := newTag(t, 2, "b")
+	// a dry run counts the new tags instead of adding them. AddBatch is not expected
+	tagRepo.On("CountNewTags", mock.Anything, []service.TatoebaTagAddParameter{tag1, tag2}, []domain.Lang3(nil)).Return(1, nil)
+	// read: 2, imported: 1, skipped: 1
+	jobRepo.On("UpdateImportJobProgress", mock.Anything, testJobID, 2, 1, 0, 0, 1, 0).Return(nil)
+
+	_, err = u.ImportTags(ctx, newTagIterator(t, tag1, tag2), nopCloser{}, option)
+	require.NoError(t, err)
+	waitImportJob(t, result)
+}
+
+func Test_adminUsecase_ImportSentences_syncRemovesAbsentSentences(t *testing.T) {
+	ctx := context.Background()
+	u, rf, jobRepo, result := newAdminUsecaseForTest(t, service.ImportJobTypeSentence)
+	sentenceRepo := mocks.NewTatoebaSentenceRepository(t)
+	rf.On("NewTatoebaSentenceRepository", mock.Anything).Return(sentenceRepo, nil)
+	linkRepo := mocks.NewTatoebaLinkRepository(t)
+	rf.On("NewTatoebaLinkRepository", mock.Anything).Return(linkRepo, nil)
+
+	option, err := service.NewImportOption("", service.ImportModeSync, nil, false)
+	require.NoError(t, err)
+	onCancelRequested(t, jobRepo, option)
+	onRejectedRows(jobRepo)
+	jobRepo.On("UpdateImportJobProgress", mock.Anything, testJobID, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	eng, err := domain.NewLang3("eng")
+	require.NoError(t, err)
+	param, err := service.NewTatoebaSentenceAddParameter(1, eng, "one", "author", time.Now())
+	require.NoError(t, err)
+	iterator := new(mocks.TatoebaSentenceAddParameterIterator)
+	iterator.On("Next", mock.Anything).Return(param, nil).Once()
+	iterator.On("Next", mock.Anything).Return(nil, io.EOF)
+	sentenceRepo.On("SyncBatch", mock.Anything, []service.TatoebaSentenceAddParameter{param}).Return([]service.TatoebaSentenceSyncResult{service.TatoebaSentenceUnchanged}, nil)
+
+	stored := make([]service.TatoebaSentence, 0)
+	for _, sentenceNumber := range []int{1, 2, 3} {
+		sentence, err := service.NewTatoebaSentence(sentenceNumber, eng, "text", "author", time.Now(), 0, 0, service.TatoebaSentenceLicenseCCBY20FR, nil)
+		require.NoError(t, err)
+		stored = append(stored, sentence)
+	}
+	sentenceRepo.On("FindTatoebaSentencesByLang3", mock.Anything, eng, 0, mock.Anything).Return(stored, nil)
+	// the sentences absent from the file are removed in a call per page
+	sentenceRepo.On("RemoveTatoebaSentences", mock.Anything, []int{2, 3}).Return(2, nil).Once()
+	linkRepo.On("RefreshSentencePairCounts", mock.Anything).Return(nil)
+	sentenceRepo.On("UpdateDifficultiesAndWordCounts", mock.Anything, mock.Anything).Return(nil)
+
+	_, err = u.ImportSentences(ctx, iterator, nopCloser{}, option)
+	require.NoError(t, err)
+	waitImportJob(t, result)
+}
+
+func Test_adminUsecase_ImportTags_alreadyRunning(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(gormSQLite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	jobRepo := mocks.NewImportJobRepository(t)
+	rf := new(mocks.RepositoryFactory)
+	rf.On("NewImportJobRepository", mock.Anything).Return(jobRepo, nil)
+	rfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return rf, nil
+	}
+	u := usecase.NewAdminUsecase(db, rfFunc)
+
+	option, err := service.NewImportOption("checksum", service.ImportModeInsert, nil, false)
+	require.NoError(t, err)
+	// the jobs whose leases expired are failed first, so the running job is alive in this or another process
+	jobRepo.On("FailExpiredImportJobs", mock.Anything, mock.Anything, "interrupted").Return(0, nil)
+	runningJob, err := service.NewImportJob(1, service.ImportJobTypeTag, option, service.ImportJobStatusRunning, 0, 0, 0, 0, 0, 0, "", false, time.Now(), nil, nil)
+	require.NoError(t, err)
+	jobRepo.On("FindLatestImportJobByFileChecksum", mock.Anything, service.ImportJobTypeTag, "checksum").Return(runningJob, nil)
+
+	_, err = u.ImportTags(ctx, newTagIterator(t), nopCloser{}, option)
+	assert.ErrorIs(t, err, service.ErrImportJobAlreadyRunning)
+}
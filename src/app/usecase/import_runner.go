@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-tatoeba-api/src/lib/log"
+)
+
+// batchImport is the part of an import job which depends on the kind of rows.
+// Rows are passed as interface{} and the functions assert them to the parameter type of the kind
+type batchImport struct {
+	// name is the kind of rows in errors
+	name string
+	// next returns the next row. It returns RejectedRowError for a rejected row and io.EOF at the end
+	next func(ctx context.Context) (interface{}, error)
+	// skip is called for each row read before the checkpoint when the job resumes. It can be nil
+	skip func(ctx context.Context, row interface{}) error
+	// importBatch writes the rows in the transaction of the batch and adds the results to progress. last is true if the batch reaches the end of the file
+	importBatch func(ctx context.Context, rf service.RepositoryFactory, rows []interface{}, last bool, progress *importProgress) error
+	// finish is called after the last batch even if the job is cancelled. It can be nil
+	finish func(ctx context.Context, cancelled bool, progress *importProgress) error
+}
+
+// runBatchImport skips the rows until the checkpoint and imports the rest in batches of commitSize rows.
+// Each batch is written in a transaction with the progress of the job and the rejected rows, so that the job can resume after the last batch.
+// It returns true if the cancellation is requested, which stops the job after the batch
+func (u *adminUsecase) runBatchImport(ctx context.Context, jobID int, progress importProgress, b *batchImport) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	var loop = true
+	var cancelled = false
+	rejectedRows := make([]service.ImportRejectedRow, 0)
+
+	// skip the rows imported until the checkpoint. their rejected rows are added to the report of this job
+	for i := 0; i < progress.readCount; i++ {
+		row, err := b.next(ctx)
+		if errors.Is(err, io.EOF) {
+			loop = false
+			break
+		}
+		var rejected *service.RejectedRowError
+		if errors.As(err, &rejected) {
+			if len(rejectedRows) < maxRejectedRows {
+				rejectedRow, err := service.NewImportRejectedRow(rejected.RowNumber, rejected.Code, rejected.Reason)
+				if err != nil {
+					return false, err
+				}
+				rejectedRows = append(rejectedRows, rejectedRow)
+			}
+			continue
+		}
+		if err != nil {
+			return false, liberrors.Errorf("read next line. read count: %d, err: %w", i+1, err)
+		}
+		if row != nil && b.skip != nil {
+			if err := b.skip(ctx, row); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	for loop {
+		if err := u.db.Transaction(func(tx *gorm.DB) error {
+			rf, err := u.rfFunc(ctx, tx)
+			if err != nil {
+				return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+			}
+
+			prevReadCount := progress.readCount
+			rows := make([]interface{}, 0, commitSize)
+			for len(rows) < commitSize {
+				row, err := b.next(ctx)
+				if errors.Is(err, io.EOF) {
+					loop = false
+					break
+				}
+				progress.readCount++
+				var rejected *service.RejectedRowError
+				if errors.As(err, &rejected) {
+					progress.skipCount++
+					progress.rejectCount++
+					if progress.rejectCount <= maxRejectedRows {
+						rejectedRow, err := service.NewImportRejectedRow(rejected.RowNumber, rejected.Code, rejected.Reason)
+						if err != nil {
+							return err
+						}
+						rejectedRows = append(rejectedRows, rejectedRow)
+					}
+					continue
+				}
+				if err != nil {
+					return liberrors.Errorf("read next line. read count: %d, err: %w", progress.readCount, err)
+				}
+				if row == nil {
+					progress.skipCount++
+					continue
+				}
+
+				rows = append(rows, row)
+			}
+
+			if err := b.importBatch(ctx, rf, rows, !loop, &progress); err != nil {
+				return liberrors.Errorf("import %ss. read count: %d, err: %w", b.name, progress.readCount, err)
+			}
+
+			if prevReadCount/logSize != progress.readCount/logSize {
+				logger.Infof("read count: %d", progress.readCount)
+			}
+
+			tmpCancelled, err := u.updateImportJobProgress(ctx, rf, jobID, progress, rejectedRows)
+			if err != nil {
+				return liberrors.Errorf("update import job progress. err: %w", err)
+			}
+			rejectedRows = rejectedRows[:0]
+			if tmpCancelled {
+				cancelled = true
+				loop = false
+			}
+
+			return nil
+		}); err != nil {
+			return false, liberrors.Errorf("import %s. err: %w", b.name, err)
+		}
+	}
+
+	if b.finish != nil {
+		prevProgress := progress
+		if err := b.finish(ctx, cancelled, &progress); err != nil {
+			return false, err
+		}
+
+		if progress != prevProgress {
+			if err := u.db.Transaction(func(tx *gorm.DB) error {
+				rf, err := u.rfFunc(ctx, tx)
+				if err != nil {
+					return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+				}
+
+				if _, err := u.updateImportJobProgress(ctx, rf, jobID, progress, nil); err != nil {
+					return liberrors.Errorf("update import job progress. err: %w", err)
+				}
+				return nil
+			}); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	logger.Infof("imported count: %d", progress.importCount)
+	logger.Infof("updated count: %d", progress.updateCount)
+	logger.Infof("deleted count: %d", progress.deleteCount)
+	logger.Infof("skipped count: %d", progress.skipCount)
+	logger.Infof("rejected count: %d", progress.rejectCount)
+	logger.Infof("read count: %d", progress.readCount)
+
+	return cancelled, nil
+}
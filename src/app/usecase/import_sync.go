@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-tatoeba-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-tatoeba-api/src/lib/errors"
+)
+
+// numberSet is a bitset of sentence numbers or audio IDs, which are dense enough to hold all of a dump in a few megabytes
+type numberSet struct {
+	bits []uint64
+}
+
+func (s *numberSet) add(number int) {
+	i := number / 64
+	if i >= len(s.bits) {
+		s.bits = append(s.bits, make([]uint64, i-len(s.bits)+1)...)
+	}
+	s.bits[i] |= 1 << (uint(number) % 64)
+}
+
+func (s *numberSet) contains(number int) bool {
+	i := number / 64
+	if i >= len(s.bits) {
+		return false
+	}
+	return s.bits[i]&(1<<(uint(number)%64)) != 0
+}
+
+// linkSync removes the links absent from a file sorted by the source sentence number.
+// The links of a source are compared when the next source appears, and the sources between them lose all their links.
+// A dry run only counts the links to remove
+type linkSync struct {
+	prevFrom int
+	from     int
+	tos      []int
+	dryRun   bool
+}
+
+// add records the link and returns the number of removed links. repo is nil while the rows before the checkpoint are skipped
+func (s *linkSync) add(ctx context.Context, repo service.TatoebaLinkRepository, param service.TatoebaLinkAddParameter) (int, error) {
+	if param.GetFrom() < s.from {
+		return 0, liberrors.Errorf("links are not sorted by the source sentence number. from: %d, previous from: %d, err: %w", param.GetFrom(), s.from, libD.ErrInvalidArgument)
+	}
+
+	removeCount := 0
+	if param.GetFrom() > s.from {
+		if repo != nil {
+			count, err := s.flush(ctx, repo)
+			if err != nil {
+				return 0, err
+			}
+			removeCount = count
+		}
+		s.prevFrom = s.from
+		s.from = param.GetFrom()
+		s.tos = nil
+	}
+
+	s.tos = append(s.tos, param.GetTo())
+	return removeCount, nil
+}
+
+// finish removes the links of the sources after the last one in the file and returns the number of removed links
+func (s *linkSync) finish(ctx context.Context, repo service.TatoebaLinkRepository) (int, error) {
+	removeCount, err := s.flush(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	removeLinksByFromRange := repo.RemoveLinksByFromRange
+	if s.dryRun {
+		removeLinksByFromRange = repo.CountLinksByFromRange
+	}
+
+	count, err := removeLinksByFromRange(ctx, s.from, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	return removeCount + count, nil
+}
+
+// flush removes the links of the current source absent from the file and the links of the sources skipped before it
+func (s *linkSync) flush(ctx context.Context, repo service.TatoebaLinkRepository) (int, error) {
+	if s.from == 0 {
+		return 0, nil
+	}
+
+	removeLinksByFromRange, removeLinksByFrom := repo.RemoveLinksByFromRange, repo.RemoveLinksByFrom
+	if s.dryRun {
+		removeLinksByFromRange, removeLinksByFrom = repo.CountLinksByFromRange, repo.CountLinksByFrom
+	}
+
+	skippedCount, err := removeLinksByFromRange(ctx, s.prevFrom, s.from)
+	if err != nil {
+		return 0, err
+	}
+
+	removedCount, err := removeLinksByFrom(ctx, s.from, s.tos)
+	if err != nil {
+		return 0, err
+	}
+
+	return skippedCount + removedCount, nil
+}
+
+// tagSync removes the tags absent from a file sorted by the sentence number in the same way as linkSync
+type tagSync struct {
+	prevSentenceNumber int
+	sentenceNumber     int
+	tagNames           []string
+	dryRun             bool
+}
+
+// add records the tag and returns the number of removed tags. repo is nil while the rows before the checkpoint are skipped
+func (s *tagSync) add(ctx context.Context, repo service.TatoebaTagRepository, param service.TatoebaTagAddParameter) (int, error) {
+	if param.GetSentenceNumber() < s.sentenceNumber {
+		return 0, liberrors.Errorf("tags are not sorted by the sentence number. sentenceNumber: %d, previous sentenceNumber: %d, err: %w", param.GetSentenceNumber(), s.sentenceNumber, libD.ErrInvalidArgument)
+	}
+
+	removeCount := 0
+	if param.GetSentenceNumber() > s.sentenceNumber {
+		if repo != nil {
+			count, err := s.flush(ctx, repo)
+			if err != nil {
+				return 0, err
+			}
+			removeCount = count
+		}
+		s.prevSentenceNumber = s.sentenceNumber
+		s.sentenceNumber = param.GetSentenceNumber()
+		s.tagNames = nil
+	}
+
+	s.tagNames = append(s.tagNames, param.GetTagName())
+	return removeCount, nil
+}
+
+// finish removes the tags of the sentences after the last one in the file and returns the number of removed tags
+func (s *tagSync) finish(ctx context.Context, repo service.TatoebaTagRepository) (int, error) {
+	removeCount, err := s.flush(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	removeTagsBySentenceNumberRange := repo.RemoveTagsBySentenceNumberRange
+	if s.dryRun {
+		removeTagsBySentenceNumberRange = repo.CountTagsBySentenceNumberRange
+	}
+
+	count, err := removeTagsBySentenceNumberRange(ctx, s.sentenceNumber, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	return removeCount + count, nil
+}
+
+// flush removes the tags of the current sentence absent from the file and the tags of the sentences skipped before it
+func (s *tagSync) flush(ctx context.Context, repo service.TatoebaTagRepository) (int, error) {
+	if s.sentenceNumber == 0 {
+		return 0, nil
+	}
+
+	removeTagsBySentenceNumberRange, removeTagsBySentenceNumber := repo.RemoveTagsBySentenceNumberRange, repo.RemoveTagsBySentenceNumber
+	if s.dryRun {
+		removeTagsBySentenceNumberRange, removeTagsBySentenceNumber = repo.CountTagsBySentenceNumberRange, repo.CountTagsBySentenceNumber
+	}
+
+	skippedCount, err := removeTagsBySentenceNumberRange(ctx, s.prevSentenceNumber, s.sentenceNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	removedCount, err := removeTagsBySentenceNumber(ctx, s.sentenceNumber, s.tagNames)
+	if err != nil {
+		return 0, err
+	}
+
+	return skippedCount + removedCount, nil
+}
@@ -13,6 +13,12 @@ type UserUsecase interface {
 	FindSentencePairs(ctx context.Context, param service.TatoebaSentenceSearchCondition) (service.TatoebaSentencePairSearchResult, error)
 
 	FindSentenceBySentenceNumber(ctx context.Context, sentenceNumber int) (service.TatoebaSentence, error)
+
+	// FindSentencesBySentenceNumbers returns the found sentences in the requested order and the sentence numbers which are not found
+	FindSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int) ([]service.TatoebaSentence, []int, error)
+
+	// FindTranslationsBySentenceNumber returns the sentence and pairs of the sentence and its translations
+	FindTranslationsBySentenceNumber(ctx context.Context, sentenceNumber int, includeIndirect bool) (service.TatoebaSentence, []service.TatoebaSentencePair, error)
 }
 
 type userUsecase struct {
@@ -76,3 +82,81 @@ func (u *userUsecase) FindSentenceBySentenceNumber(ctx context.Context, sentence
 	}
 	return result, nil
 }
+
+func (u *userUsecase) FindSentencesBySentenceNumbers(ctx context.Context, sentenceNumbers []int) ([]service.TatoebaSentence, []int, error) {
+	var sentences []service.TatoebaSentence
+	if err := u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewTatoebaSentenceRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new TatoebaSentenceRepository. err: %w", err)
+		}
+
+		tmpSentences, err := repo.FindTatoebaSentencesBySentenceNumbers(ctx, sentenceNumbers)
+		if err != nil {
+			return liberrors.Errorf("execute FindTatoebaSentencesBySentenceNumbers. err: %w", err)
+		}
+		sentences = tmpSentences
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	sentenceMap := make(map[int]service.TatoebaSentence)
+	for _, sentence := range sentences {
+		sentenceMap[sentence.GetSentenceNumber()] = sentence
+	}
+
+	found := make([]service.TatoebaSentence, 0, len(sentences))
+	missing := make([]int, 0)
+	checked := make(map[int]bool)
+	for _, sentenceNumber := range sentenceNumbers {
+		if checked[sentenceNumber] {
+			continue
+		}
+		checked[sentenceNumber] = true
+
+		if sentence, ok := sentenceMap[sentenceNumber]; ok {
+			found = append(found, sentence)
+		} else {
+			missing = append(missing, sentenceNumber)
+		}
+	}
+	return found, missing, nil
+}
+
+func (u *userUsecase) FindTranslationsBySentenceNumber(ctx context.Context, sentenceNumber int, includeIndirect bool) (service.TatoebaSentence, []service.TatoebaSentencePair, error) {
+	var sentence service.TatoebaSentence
+	var translations []service.TatoebaSentencePair
+	if err := u.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := u.rfFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("create RepositoryFactory. err: %w", err)
+		}
+
+		repo, err := rf.NewTatoebaSentenceRepository(ctx)
+		if err != nil {
+			return liberrors.Errorf("new TatoebaSentenceRepository. err: %w", err)
+		}
+
+		tmpSentence, err := repo.FindTatoebaSentenceBySentenceNumber(ctx, sentenceNumber)
+		if err != nil {
+			return liberrors.Errorf("execute FindTatoebaSentenceBySentenceNumber. err: %w", err)
+		}
+
+		tmpTranslations, err := repo.FindTranslationsBySentenceNumber(ctx, sentenceNumber, includeIndirect)
+		if err != nil {
+			return liberrors.Errorf("execute FindTranslationsBySentenceNumber. err: %w", err)
+		}
+		sentence = tmpSentence
+		translations = tmpTranslations
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return sentence, translations, nil
+}
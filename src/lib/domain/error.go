@@ -1,5 +1,77 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
 
 var ErrInvalidArgument = errors.New("invalid argument")
+
+// InvalidArgumentError is the error of an argument of a request. Its message only describes the argument, so it can be returned to the client.
+// errors.Is reports that it is ErrInvalidArgument
+type InvalidArgumentError struct {
+	Name   string
+	Reason string
+	Err    error
+}
+
+// NewInvalidArgumentError returns the error of the argument with the reason
+func NewInvalidArgumentError(name, reason string) error {
+	return &InvalidArgumentError{Name: name, Reason: reason}
+}
+
+// ToInvalidArgumentError returns the error of the argument which err rejects. Validation errors are described by their fields and rules
+func ToInvalidArgumentError(name string, err error) error {
+	return &InvalidArgumentError{Name: name, Reason: describeError(err), Err: err}
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("invalid %s. %s", e.Name, e.Reason)
+}
+
+func (e *InvalidArgumentError) Unwrap() error {
+	return e.Err
+}
+
+func (e *InvalidArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// describeError returns "field must satisfy rule" for each field of the validation errors, and the message of the other errors
+func describeError(err error) string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err.Error()
+	}
+
+	reasons := make([]string, len(validationErrors))
+	for i, fieldError := range validationErrors {
+		rule := fieldError.Tag()
+		if fieldError.Param() != "" {
+			rule += "=" + fieldError.Param()
+		}
+		reasons[i] = fmt.Sprintf("%s must satisfy %s", fieldPath(fieldError.Namespace()), rule)
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// fieldPath converts the namespace of a field, such as tatoebaSentenceFindParameter.SrcFilter.Tags, to the name in requests, such as srcFilter.tags
+func fieldPath(namespace string) string {
+	names := strings.Split(namespace, ".")
+	if len(names) > 1 {
+		// the first name is the struct
+		names = names[1:]
+	}
+	for i, name := range names {
+		runes := []rune(name)
+		if len(runes) > 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		}
+		names[i] = string(runes)
+	}
+	return strings.Join(names, ".")
+}
@@ -126,7 +126,7 @@ func httpServer(ctx context.Context, cfg *config.Config, db *gorm.DB, rfFunc ser
 	adminUsecase := usecase.NewAdminUsecase(db, rfFunc)
 	userUsecase := usecase.NewUserUsecase(db, rfFunc)
 
-	router := controller.NewRouter(adminUsecase, userUsecase, corsConfig, cfg.App, cfg.Auth, cfg.Debug)
+	router := controller.NewRouter(adminUsecase, userUsecase, corsConfig, cfg.App, cfg.Auth, cfg.Import, cfg.Debug)
 
 	if cfg.Swagger.Enabled {
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kujilabo/cocotola-tatoeba-api/src/app/config"
+)
+
+var timeoutImportMin = 30
+var timeoutJobStatusSec = 10
+var pollIntervalSec = 10
+
+// importFile is the path of the import API and the default file of each kind of rows
+type importFile struct {
+	path     string
+	filename string
+}
+
+// importFiles maps the kinds to the files. links2.csv is links.csv filtered to the imported sentences
+var importFiles = map[string]importFile{
+	"sentence": {path: "sentence/import", filename: "eng_sentences_detailed.tsv"},
+	"link":     {path: "link/import", filename: "links2.csv"},
+	"tag":      {path: "tag/import", filename: "tags.csv"},
+	"audio":    {path: "audio/import", filename: "sentences_with_audio.csv"},
+	"license":  {path: "license/import", filename: "sentences_CC0.csv"},
+}
+
+// main uploads a file of the Tatoeba dump to the import API and waits for the import job.
+// For example, go run ./tools/tatoeba_import -kind tag -mode sync or go run ./tools/tatoeba_import -kind sentence -file jpn_sentences_detailed.tsv
+func main() {
+	kind := flag.String("kind", "", "kind of rows. sentence, link, tag, audio or license")
+	filename := flag.String("file", "", "file in ../cocotola-data/datasource/tatoeba. the default file of the kind if empty")
+	// sync also updates changed rows and removes rows absent from the file
+	importMode := flag.String("mode", "insert", "insert or sync")
+	// dryRun only validates the file and counts the rows which would be written
+	dryRun := flag.Bool("dryRun", false, "validate the file and count the rows without writing them")
+	flag.Parse()
+
+	target, ok := importFiles[*kind]
+	if !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *filename == "" {
+		*filename = target.filename
+	}
+
+	cfg, err := config.LoadConfig("local")
+	if err != nil {
+		panic(err)
+	}
+
+	url := "http://localhost:8280/v1/admin/" + target.path
+	fieldname := "file"
+
+	file, err := os.Open("../cocotola-data/datasource/tatoeba/" + *filename)
+	if err != nil {
+		panic(err)
+	}
+
+	body := bytes.Buffer{}
+
+	mw := multipart.NewWriter(&body)
+
+	if err := mw.WriteField("mode", *importMode); err != nil {
+		panic(err)
+	}
+
+	if err := mw.WriteField("dryRun", strconv.FormatBool(*dryRun)); err != nil {
+		panic(err)
+	}
+
+	fw, err := mw.CreateFormFile(fieldname, *filename)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := io.Copy(fw, file); err != nil {
+		panic(err)
+	}
+
+	if err = mw.Close(); err != nil {
+		panic(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		panic(err)
+	}
+
+	req.SetBasicAuth(cfg.Auth.Username, cfg.Auth.Password)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	client := http.Client{
+		Timeout: time.Duration(timeoutImportMin) * time.Minute,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("status: %d\n", resp.StatusCode)
+	fmt.Printf("body: %s\n", string(respBody))
+	if resp.StatusCode != http.StatusAccepted {
+		return
+	}
+
+	job := struct {
+		JobID int `json:"jobId"`
+	}{}
+	if err := json.Unmarshal(respBody, &job); err != nil {
+		panic(err)
+	}
+
+	if err := waitJob(cfg, job.JobID); err != nil {
+		panic(err)
+	}
+}
+
+// waitJob polls the import job until it finishes
+func waitJob(cfg *config.Config, jobID int) error {
+	url := "http://localhost:8280/v1/admin/job/" + strconv.Itoa(jobID)
+	client := http.Client{
+		Timeout: time.Duration(timeoutJobStatusSec) * time.Second,
+	}
+
+	for {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.SetBasicAuth(cfg.Auth.Username, cfg.Auth.Password)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		job := struct {
+			Status string `json:"status"`
+		}{}
+		if err := json.Unmarshal(respBody, &job); err != nil {
+			return err
+		}
+		fmt.Printf("job: %s\n", string(respBody))
+		if job.Status != "running" {
+			return nil
+		}
+
+		time.Sleep(time.Duration(pollIntervalSec) * time.Second)
+	}
+}
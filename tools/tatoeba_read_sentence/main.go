@@ -14,6 +14,8 @@ import (
 	"github.com/kujilabo/cocotola-tatoeba-api/src/app/gateway"
 )
 
+const textLimitLength = 100
+
 func run(fileName string) (map[int]bool, error) {
 	filePath := "../cocotola-data/datasource/tatoeba/" + fileName
 
@@ -23,7 +25,7 @@ func run(fileName string) (map[int]bool, error) {
 	}
 	defer file.Close()
 
-	iterator := gateway.NewTatoebaSentenceAddParameterReader(file)
+	iterator := gateway.NewTatoebaSentenceAddParameterReader(file, textLimitLength)
 
 	sentenceNumbers := make(map[int]bool)
 	for {